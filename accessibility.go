@@ -0,0 +1,245 @@
+package etk
+
+import (
+	"image"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// Role represents the type of an accessible widget.
+type Role int
+
+// Accessible roles.
+const (
+	// RoleNone is a widget without a specific role.
+	RoleNone Role = iota
+
+	// RoleButton is a widget which performs an action when selected.
+	RoleButton
+
+	// RoleCheckbox is a widget which may be checked and unchecked.
+	RoleCheckbox
+
+	// RoleList is a list of items.
+	RoleList
+
+	// RoleListItem is an item within a list.
+	RoleListItem
+
+	// RoleTextbox is a widget which accepts text input.
+	RoleTextbox
+
+	// RoleComboBox is a widget which shows the selected item of a dropdown
+	// list.
+	RoleComboBox
+
+	// RoleWindow is a window.
+	RoleWindow
+
+	// RoleText is a widget which displays text.
+	RoleText
+)
+
+var roleNames = []string{"none", "button", "checkbox", "list", "listitem", "textbox", "combobox", "window", "text"}
+
+// String returns the name of the role.
+func (r Role) String() string {
+	if r < 0 || int(r) >= len(roleNames) {
+		return roleNames[RoleNone]
+	}
+	return roleNames[r]
+}
+
+// Accessibility describes a widget to assistive technology and automated user
+// interface tests.
+type Accessibility struct {
+	// Role is the type of the widget.
+	Role Role
+
+	// Name is the label of the widget, such as the text of a button.
+	Name string
+
+	// Value is the current value of the widget, such as the text of an input
+	// or the selected item of a dropdown list.
+	Value string
+
+	// Checked is true when the widget is checked.
+	Checked bool
+
+	// Selected is true when the widget is the selected item of a list.
+	Selected bool
+
+	// Focused is true when the widget is focused.
+	Focused bool
+
+	// Disabled is true when the widget does not accept user input.
+	Disabled bool
+}
+
+// Accessible may be implemented by widgets to describe themselves to
+// assistive technology and automated user interface tests. Any widget may be
+// given an accessible name via WithAccessibleName.
+type Accessible interface {
+	// Accessibility returns the role, name, value and state of the widget.
+	Accessibility() Accessibility
+}
+
+// WithAccessibleName wraps a widget to set its accessible name, such as to
+// label an Input or Checkbox. When the wrapped widget does not implement
+// Accessible, the widget is given the role RoleNone.
+type WithAccessibleName struct {
+	Widget
+	Name string
+}
+
+// Accessibility returns the role, name, value and state of the widget.
+func (w *WithAccessibleName) Accessibility() Accessibility {
+	var a Accessibility
+	accessible := findAccessible(w.Widget)
+	if accessible != nil {
+		a = accessible.Accessibility()
+	}
+	a.Name = w.Name
+	return a
+}
+
+// findAccessible returns the provided widget, or the widget wrapped by it, as
+// an Accessible.
+func findAccessible(w Widget) Accessible {
+	for w != nil {
+		accessible, ok := w.(Accessible)
+		if ok {
+			return accessible
+		}
+		w = unwrap(w)
+	}
+	return nil
+}
+
+// accessibleParent is implemented by widgets which provide accessible nodes
+// for their items instead of those found by walking their children.
+type accessibleParent interface {
+	accessibleChildren(walk func(w Widget) []*AccessibleNode) []*AccessibleNode
+}
+
+// findAccessibleParent returns the provided widget, or the widget wrapped by
+// it, as an accessibleParent.
+func findAccessibleParent(w Widget) accessibleParent {
+	for w != nil {
+		parent, ok := w.(accessibleParent)
+		if ok {
+			return parent
+		}
+		w = unwrap(w)
+	}
+	return nil
+}
+
+// AccessibleNode is a visible accessible widget within an accessibility tree.
+type AccessibleNode struct {
+	Accessibility
+
+	// Widget is the widget described by the node. Nodes created for list
+	// items have a nil Widget.
+	Widget Widget
+
+	// Rect is the position and size of the widget.
+	Rect image.Rectangle
+
+	// Children are the accessible descendants of the widget.
+	Children []*AccessibleNode
+}
+
+// Find returns the first node within the tree, including the node itself,
+// which has the provided role and name. Nodes are searched depth-first.
+// If no node is found, nil is returned.
+func (n *AccessibleNode) Find(role Role, name string) *AccessibleNode {
+	if n.Role == role && n.Name == name {
+		return n
+	}
+	for _, child := range n.Children {
+		found := child.Find(role, name)
+		if found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// AccessibilityTree returns the accessibility tree of the default UI. See
+// UI.AccessibilityTree.
+func AccessibilityTree() []*AccessibleNode {
+	return activeUI().AccessibilityTree()
+}
+
+// AccessibilityTree walks the root widget and any modal widgets and returns
+// the visible widgets which implement Accessible as a tree. Widgets which do
+// not implement Accessible are omitted, and their accessible descendants are
+// attached to the nearest accessible ancestor. The accessible widgets of the
+// root widget are returned first, followed by those of each modal widget from
+// bottom to top.
+func (u *UI) AccessibilityTree() []*AccessibleNode {
+	nodes := u.accessibleNodes(u.root)
+	for _, m := range u.modals {
+		nodes = append(nodes, u.accessibleNodes(m.w)...)
+	}
+	return nodes
+}
+
+// accessibleNodes returns the accessible nodes of a widget and its children.
+func (u *UI) accessibleNodes(w Widget) []*AccessibleNode {
+	if w == nil || !w.Visible() {
+		return nil
+	}
+
+	var children []*AccessibleNode
+	parent := findAccessibleParent(w)
+	if parent != nil {
+		children = parent.accessibleChildren(u.accessibleNodes)
+	} else {
+		for _, child := range w.Children() {
+			children = append(children, u.accessibleNodes(child)...)
+		}
+	}
+
+	accessible := findAccessible(w)
+	if accessible == nil {
+		return children
+	}
+	node := &AccessibleNode{
+		Accessibility: accessible.Accessibility(),
+		Widget:        w,
+		Rect:          w.Rect(),
+		Children:      children,
+	}
+	if w == u.focusedWidget {
+		node.Focused = true
+	}
+	return []*AccessibleNode{node}
+}
+
+// accessibleName returns the names of the provided nodes separated by spaces.
+func accessibleName(nodes []*AccessibleNode) string {
+	var names []string
+	for _, node := range nodes {
+		name := node.Name
+		if name == "" {
+			name = accessibleName(node.Children)
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// maskText returns the provided text with each grapheme cluster replaced by the
+// mask rune.
+// When the mask rune is 0, the text is returned unchanged.
+func maskText(text string, mask rune) string {
+	if mask == 0 {
+		return text
+	}
+	return strings.Repeat(string(mask), uniseg.GraphemeClusterCount(text))
+}
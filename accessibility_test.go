@@ -0,0 +1,57 @@
+package etk_test
+
+import (
+	"testing"
+
+	"codeberg.org/tslocum/etk"
+)
+
+func TestAccessibilityTree(t *testing.T) {
+	setupStyle(t)
+
+	button := etk.NewButton("OK", nil)
+	checkbox := etk.NewCheckbox(nil)
+	checkbox.SetSelected(true)
+	input := etk.NewInput("secret", nil, nil)
+	input.SetMask('*')
+	list := etk.NewList(20, nil, nil)
+	list.AddChildAt(etk.NewText("First"), 0, 0)
+	list.AddChildAt(etk.NewText("Second"), 0, 1)
+	list.SetSelectedItem(0, 1)
+
+	g := etk.NewGrid()
+	g.AddChildAt(button, 0, 0, 1, 1)
+	g.AddChildAt(&etk.WithAccessibleName{Widget: checkbox, Name: "Remember"}, 1, 0, 1, 1)
+	g.AddChildAt(&etk.WithAccessibleName{Widget: input, Name: "Password"}, 2, 0, 1, 1)
+	g.AddChildAt(list, 3, 0, 1, 1)
+	u, _, _, _ := setupFakeInput(t, g)
+	u.Layout(400, 100)
+	u.SetFocus(button)
+
+	nodes := u.AccessibilityTree()
+	if len(nodes) != 4 {
+		t.Fatalf("expected 4 top-level nodes, got %d", len(nodes))
+	}
+	root := &etk.AccessibleNode{Children: nodes}
+
+	b := root.Find(etk.RoleButton, "OK")
+	if b == nil || !b.Focused || b.Widget != button {
+		t.Fatalf("unexpected button node: %+v", b)
+	}
+	c := root.Find(etk.RoleCheckbox, "Remember")
+	if c == nil || !c.Checked || c.Focused {
+		t.Fatalf("unexpected checkbox node: %+v", c)
+	}
+	i := root.Find(etk.RoleTextbox, "Password")
+	if i == nil || i.Value != "******" {
+		t.Fatalf("unexpected textbox node: %+v", i)
+	}
+	l := root.Find(etk.RoleList, "")
+	if l == nil || len(l.Children) != 2 {
+		t.Fatalf("unexpected list node: %+v", l)
+	}
+	first, second := l.Find(etk.RoleListItem, "First"), l.Find(etk.RoleListItem, "Second")
+	if first == nil || first.Selected || second == nil || !second.Selected {
+		t.Fatalf("unexpected list items: %+v, %+v", first, second)
+	}
+}
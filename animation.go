@@ -0,0 +1,216 @@
+package etk
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// Easing maps the progress of an animation, from 0 to 1, to the progress of
+// the animated value. Easing functions return 0 at the start of an animation
+// and 1 at the end.
+type Easing func(t float64) float64
+
+// EaseLinear changes the animated value at a constant rate.
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad starts slowly and accelerates.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad starts quickly and decelerates.
+func EaseOutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+// EaseInOutQuad accelerates until halfway and then decelerates.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// EaseOutCubic starts quickly and decelerates more than EaseOutQuad.
+func EaseOutCubic(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+var reducedMotion atomic.Bool
+
+// ReducedMotion returns whether animations are disabled.
+func ReducedMotion() bool {
+	return reducedMotion.Load()
+}
+
+// SetReducedMotion sets whether animations are disabled, such as for users
+// who prefer reduced motion. While animations are disabled, animations
+// complete the next time the UI is updated and transitions are not shown.
+func SetReducedMotion(reduced bool) {
+	reducedMotion.Store(reduced)
+}
+
+// Animation animates a value over time. Animations are advanced each time the
+// UI they were started in is updated.
+type Animation struct {
+	duration time.Duration
+	easing   Easing
+	step     func(v float64)
+	done     func()
+	start    time.Time
+	finished bool
+}
+
+// Animate starts an animation within the active UI. See UI.Animate.
+func Animate(duration time.Duration, easing Easing, step func(v float64), done func()) *Animation {
+	return activeUI().Animate(duration, easing, step, done)
+}
+
+// Animate starts an animation. The animation begins the next time the UI is
+// updated. Each time the UI is updated, step is called with the eased progress
+// of the animation, from 0 to 1, until the duration has elapsed. After step is
+// called with a final value of 1, done is called. The done function may be nil.
+// A nil easing function is treated as EaseLinear.
+func (u *UI) Animate(duration time.Duration, easing Easing, step func(v float64), done func()) *Animation {
+	if easing == nil {
+		easing = EaseLinear
+	}
+	a := &Animation{
+		duration: duration,
+		easing:   easing,
+		step:     step,
+		done:     done,
+	}
+	u.animations = append(u.animations, a)
+	u.invalidate()
+	return a
+}
+
+// Stop stops the animation without completing it. The done function is not
+// called.
+func (a *Animation) Stop() {
+	a.finished = true
+}
+
+// Finish completes the animation immediately.
+func (a *Animation) Finish() {
+	if a.finished {
+		return
+	}
+	a.finished = true
+	if a.step != nil {
+		a.step(1)
+	}
+	if a.done != nil {
+		a.done()
+	}
+}
+
+// Done returns whether the animation has completed or was stopped.
+func (a *Animation) Done() bool {
+	return a.finished
+}
+
+// updateAnimations advances all animations.
+func (u *UI) updateAnimations() {
+	if len(u.animations) == 0 {
+		return
+	}
+	now := time.Now()
+	reduced := ReducedMotion()
+
+	// Animations may be started while others are advanced.
+	animations := u.animations
+	u.animations = nil
+	var active []*Animation
+	for _, a := range animations {
+		if a.finished {
+			continue
+		} else if a.start.IsZero() {
+			a.start = now
+		}
+		t := 1.0
+		if !reduced && a.duration > 0 {
+			t = float64(now.Sub(a.start)) / float64(a.duration)
+		}
+		if t >= 1 {
+			a.Finish()
+			continue
+		}
+		if a.step != nil {
+			a.step(a.easing(t))
+		}
+		active = append(active, a)
+	}
+	u.animations = append(active, u.animations...)
+	u.invalidate()
+}
+
+// TweenFloat returns the value between from and to at the provided progress.
+func TweenFloat(from float64, to float64, t float64) float64 {
+	return from + (to-from)*t
+}
+
+// TweenInt returns the value between from and to at the provided progress,
+// rounded to the nearest integer.
+func TweenInt(from int, to int, t float64) int {
+	return from + int(math.Round(float64(to-from)*t))
+}
+
+// TweenRect returns the rectangle between from and to at the provided
+// progress.
+func TweenRect(from image.Rectangle, to image.Rectangle, t float64) image.Rectangle {
+	return image.Rect(TweenInt(from.Min.X, to.Min.X, t), TweenInt(from.Min.Y, to.Min.Y, t), TweenInt(from.Max.X, to.Max.X, t), TweenInt(from.Max.Y, to.Max.Y, t))
+}
+
+// TweenColor returns the color between from and to at the provided progress.
+func TweenColor(from color.RGBA, to color.RGBA, t float64) color.RGBA {
+	c := func(a uint8, b uint8) uint8 {
+		return uint8(max(0, min(255, TweenInt(int(a), int(b), t))))
+	}
+	return color.RGBA{c(from.R, to.R), c(from.G, to.G), c(from.B, to.B), c(from.A, to.A)}
+}
+
+// AnimateRect animates the position and size of a widget within the active
+// UI. Widgets positioned by a layout are moved back into place when the
+// layout changes.
+func AnimateRect(w Widget, to image.Rectangle, duration time.Duration, easing Easing, done func()) *Animation {
+	from := w.Rect()
+	return Animate(duration, easing, func(v float64) {
+		w.SetRect(TweenRect(from, to, v))
+		MarkDirty(w)
+	}, done)
+}
+
+// AnimateColor animates a color within the active UI. The set function is
+// called with the color each time the animation advances.
+func AnimateColor(from color.RGBA, to color.RGBA, duration time.Duration, easing Easing, set func(c color.RGBA), done func()) *Animation {
+	return Animate(duration, easing, func(v float64) {
+		set(TweenColor(from, to, v))
+	}, done)
+}
+
+// AnimateAlpha animates the opacity of a widget within the active UI. See
+// UI.SetAlpha.
+func AnimateAlpha(w Widget, to float64, duration time.Duration, easing Easing, done func()) *Animation {
+	u := activeUI()
+	from := u.Alpha(w)
+	return u.Animate(duration, easing, func(v float64) {
+		u.SetAlpha(w, TweenFloat(from, to, v))
+	}, done)
+}
+
+// AnimateScroll animates the scroll offset of a list within the active UI.
+// See List.SetScrollOffset.
+func AnimateScroll(l *List, to int, duration time.Duration, easing Easing, done func()) *Animation {
+	from := l.ScrollOffset()
+	return Animate(duration, easing, func(v float64) {
+		l.SetScrollOffset(TweenInt(from, to, v))
+	}, done)
+}
@@ -0,0 +1,68 @@
+package etk_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"codeberg.org/tslocum/etk"
+)
+
+func TestAnimation(t *testing.T) {
+	u, f, a, _ := setupFakeInput(t, nil)
+
+	for _, easing := range []etk.Easing{etk.EaseLinear, etk.EaseInQuad, etk.EaseOutQuad, etk.EaseInOutQuad, etk.EaseOutCubic} {
+		if easing(0) != 0 || easing(1) != 1 {
+			t.Fatalf("easing function does not start at 0 and end at 1: %v, %v", easing(0), easing(1))
+		}
+	}
+	if r := etk.TweenRect(image.Rect(0, 0, 10, 10), image.Rect(10, 20, 30, 40), 0.5); r != image.Rect(5, 10, 20, 25) {
+		t.Fatalf("unexpected rect tween: %v", r)
+	}
+	if c := etk.TweenColor(color.RGBA{0, 0, 0, 255}, color.RGBA{200, 100, 50, 255}, 0.5); c != (color.RGBA{100, 50, 25, 255}) {
+		t.Fatalf("unexpected color tween: %v", c)
+	}
+
+	var steps []float64
+	var done int
+	anim := u.Animate(time.Hour, nil, func(v float64) {
+		steps = append(steps, v)
+	}, func() {
+		done++
+	})
+	frame(t, u, f)
+	if len(steps) != 1 || steps[0] != 0 || done != 0 || anim.Done() {
+		t.Fatalf("unexpected animation state: steps %v, done %d", steps, done)
+	}
+	anim.Finish()
+	frame(t, u, f)
+	if len(steps) != 2 || steps[1] != 1 || done != 1 || !anim.Done() {
+		t.Fatalf("unexpected animation state after finishing: steps %v, done %d", steps, done)
+	}
+
+	anim = u.Animate(time.Hour, nil, nil, func() {
+		done++
+	})
+	anim.Stop()
+	frame(t, u, f)
+	if done != 1 {
+		t.Fatal("stopped animation completed")
+	}
+
+	etk.SetReducedMotion(true)
+	defer etk.SetReducedMotion(false)
+	u.Animate(time.Hour, nil, func(v float64) {
+		u.SetAlpha(a, v)
+	}, func() {
+		done++
+	})
+	u.SetAlpha(a, 0)
+	if u.Alpha(a) != 0 {
+		t.Fatalf("unexpected alpha: %v", u.Alpha(a))
+	}
+	frame(t, u, f)
+	if done != 2 || u.Alpha(a) != 1 {
+		t.Fatalf("animation did not complete with reduced motion: done %d, alpha %v", done, u.Alpha(a))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"image"
 	"image/color"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -15,6 +16,8 @@ type Box struct {
 	children   []Widget
 	background color.RGBA
 	visible    bool
+	dirty      atomic.Bool
+	tabOrder   int
 
 	sync.Mutex
 }
@@ -60,6 +63,27 @@ func (b *Box) SetBackground(background color.RGBA) {
 	defer b.Unlock()
 
 	b.background = background
+	MarkDirty(b)
+}
+
+func (b *Box) tabIndex() int {
+	return b.tabOrder
+}
+
+func (b *Box) setTabIndex(index int) {
+	b.tabOrder = index
+}
+
+func (b *Box) markDirty() {
+	b.dirty.Store(true)
+}
+
+func (b *Box) isDirty() bool {
+	return b.dirty.Load()
+}
+
+func (b *Box) clearDirty() bool {
+	return b.dirty.Swap(false)
 }
 
 // Focus returns the focus state of the widget.
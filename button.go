@@ -14,6 +14,7 @@ type Button struct {
 	*Box
 	field         *messeji.TextField
 	btnBackground color.RGBA
+	btnHover      color.RGBA
 	textFont      *text.GoTextFaceSource
 	textSize      int
 	borderSize    int
@@ -21,8 +22,11 @@ type Button struct {
 	borderRight   color.RGBA
 	borderBottom  color.RGBA
 	borderLeft    color.RGBA
+	borderFocused color.RGBA
 	onSelect      func() error
 	pressed       bool
+	focus         bool
+	hover         bool
 }
 
 // NewButton returns a new Button widget.
@@ -42,6 +46,7 @@ func NewButton(label string, onSelect func() error) *Button {
 		Box:           NewBox(),
 		field:         f,
 		btnBackground: Style.ButtonBgColor,
+		btnHover:      Style.ButtonBgColorHover,
 		textFont:      Style.TextFont,
 		textSize:      Scale(Style.TextSize),
 		onSelect:      onSelect,
@@ -50,6 +55,7 @@ func NewButton(label string, onSelect func() error) *Button {
 		borderRight:   Style.ButtonBorderRight,
 		borderBottom:  Style.ButtonBorderBottom,
 		borderLeft:    Style.ButtonBorderLeft,
+		borderFocused: Style.ButtonBorderFocused,
 	}
 	b.SetBackground(Style.ButtonBgColor)
 	return b
@@ -85,6 +91,15 @@ func (b *Button) SetBorderColors(top color.RGBA, right color.RGBA, bottom color.
 	b.borderLeft = left
 }
 
+// SetFocusedBorderColor sets the color of the border around the button when
+// it is focused.
+func (b *Button) SetFocusedBorderColor(c color.RGBA) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.borderFocused = c
+}
+
 // SetForeground sets the color of the button label.
 func (b *Button) SetForeground(c color.RGBA) {
 	b.Lock()
@@ -102,6 +117,43 @@ func (b *Button) SetBackground(background color.RGBA) {
 	b.background = background
 }
 
+// SetHoverBackground sets the background color of the button while the mouse
+// cursor hovers over it. A transparent color disables the hover background.
+func (b *Button) SetHoverBackground(background color.RGBA) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.btnHover = background
+	MarkDirty(b)
+}
+
+// Background returns the background color of the widget.
+func (b *Button) Background() color.RGBA {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.hover && !b.pressed && b.btnHover.A != 0 {
+		return b.btnHover
+	}
+	return b.background
+}
+
+// MouseEnter is called when the mouse cursor enters the widget.
+func (b *Button) MouseEnter() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.hover = true
+}
+
+// MouseLeave is called when the mouse cursor leaves the widget.
+func (b *Button) MouseLeave() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.hover = false
+}
+
 // Text returns the content of the text buffer.
 func (b *Button) Text() string {
 	b.Lock()
@@ -116,6 +168,7 @@ func (b *Button) SetText(text string) {
 	defer b.Unlock()
 
 	b.field.SetText(text)
+	MarkDirty(b)
 }
 
 // SetFont sets the font and text size of button label. Scaling is not applied.
@@ -143,14 +196,55 @@ func (b *Button) SetVertical(v Alignment) {
 	b.field.SetVertical(messeji.Alignment(v))
 }
 
+// Focus returns the focus state of the widget.
+func (b *Button) Focus() bool {
+	return b.focus
+}
+
+// focusOnClick returns false, as the widget is only focused via focus
+// traversal. Clicking the widget leaves focus unchanged.
+func (b *Button) focusOnClick() bool {
+	return false
+}
+
+// SetFocus sets the focus state of the widget.
+func (b *Button) SetFocus(focus bool) (accept bool) {
+	b.focus = focus
+	return true
+}
+
 // Cursor returns the cursor shape shown when a mouse cursor hovers over the
 // widget, or -1 to let widgets beneath determine the cursor shape.
 func (b *Button) Cursor() ebiten.CursorShapeType {
 	return ebiten.CursorShapePointer
 }
 
-// HandleKeyboard is called when a keyboard event occurs.
-func (b *Button) HandleKeyboard(ebiten.Key, rune) (handled bool, err error) {
+// Accessibility returns the role, name, value and state of the widget.
+func (b *Button) Accessibility() Accessibility {
+	return Accessibility{
+		Role:    RoleButton,
+		Name:    b.Text(),
+		Focused: b.focus,
+	}
+}
+
+// HandleKeyboard is called when a keyboard event occurs. The button is
+// selected when a confirm key is pressed.
+func (b *Button) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
+	if r != 0 {
+		return false, nil
+	}
+	for _, confirmKey := range Bindings.ConfirmKeyboard {
+		if key == confirmKey {
+			b.Lock()
+			onSelect := b.onSelect
+			b.Unlock()
+			if onSelect == nil {
+				return true, nil
+			}
+			return true, onSelect()
+		}
+	}
 	return false, nil
 }
 
@@ -189,7 +283,12 @@ func (b *Button) Draw(screen *ebiten.Image) error {
 
 	// Draw border.
 	if b.borderSize != 0 {
-		if !b.pressed {
+		if b.focus {
+			screen.SubImage(image.Rect(r.Min.X, r.Min.Y, r.Min.X+b.borderSize, r.Max.Y)).(*ebiten.Image).Fill(b.borderFocused)
+			screen.SubImage(image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+b.borderSize)).(*ebiten.Image).Fill(b.borderFocused)
+			screen.SubImage(image.Rect(r.Max.X-b.borderSize, r.Min.Y, r.Max.X, r.Max.Y)).(*ebiten.Image).Fill(b.borderFocused)
+			screen.SubImage(image.Rect(r.Min.X, r.Max.Y-b.borderSize, r.Max.X, r.Max.Y)).(*ebiten.Image).Fill(b.borderFocused)
+		} else if !b.pressed {
 			screen.SubImage(image.Rect(r.Min.X, r.Min.Y, r.Min.X+b.borderSize, r.Max.Y)).(*ebiten.Image).Fill(b.borderLeft)
 			screen.SubImage(image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+b.borderSize)).(*ebiten.Image).Fill(b.borderTop)
 			screen.SubImage(image.Rect(r.Max.X-b.borderSize, r.Min.Y, r.Max.X, r.Max.Y)).(*ebiten.Image).Fill(b.borderRight)
@@ -12,22 +12,28 @@ import (
 type Checkbox struct {
 	*Box
 
-	selected    bool
-	checkColor  color.RGBA
-	borderSize  int
-	borderColor color.RGBA
-	img         *ebiten.Image
-	onSelect    func() error
+	selected      bool
+	focus         bool
+	checkColor    color.RGBA
+	borderSize    int
+	borderColor   color.RGBA
+	borderFocused color.RGBA
+	hoverColor    color.RGBA
+	hover         bool
+	img           *ebiten.Image
+	onSelect      func() error
 }
 
 // NewCheckbox returns a new Checkbox widget.
 func NewCheckbox(onSelect func() error) *Checkbox {
 	c := &Checkbox{
-		Box:         NewBox(),
-		checkColor:  Style.TextColorDark,
-		borderSize:  2,
-		borderColor: Style.ButtonBorderBottom,
-		onSelect:    onSelect,
+		Box:           NewBox(),
+		checkColor:    Style.TextColorDark,
+		borderSize:    2,
+		borderColor:   Style.ButtonBorderBottom,
+		borderFocused: Style.ButtonBorderFocused,
+		hoverColor:    Style.CheckboxBgColorHover,
+		onSelect:      onSelect,
 	}
 	c.SetBackground(Style.CheckboxBgColor)
 	return c
@@ -73,11 +79,55 @@ func (c *Checkbox) SetBorderColor(borderColor color.RGBA) {
 	c.updateImage()
 }
 
+// SetFocusedBorderColor sets the border color of the Checkbox when it is focused.
+func (c *Checkbox) SetFocusedBorderColor(borderColor color.RGBA) {
+	c.borderFocused = borderColor
+	c.updateImage()
+}
+
 // Selected returns the selection state of the Checkbox.
 func (c *Checkbox) Selected() bool {
 	return c.selected
 }
 
+// SetHoverBackground sets the background color of the Checkbox while the
+// mouse cursor hovers over it. A transparent color disables the hover
+// background.
+func (c *Checkbox) SetHoverBackground(background color.RGBA) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.hoverColor = background
+	MarkDirty(c)
+}
+
+// Background returns the background color of the widget.
+func (c *Checkbox) Background() color.RGBA {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.hover && c.hoverColor.A != 0 {
+		return c.hoverColor
+	}
+	return c.background
+}
+
+// MouseEnter is called when the mouse cursor enters the widget.
+func (c *Checkbox) MouseEnter() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.hover = true
+}
+
+// MouseLeave is called when the mouse cursor leaves the widget.
+func (c *Checkbox) MouseLeave() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.hover = false
+}
+
 // SetSelected sets the Checkbox selection state. The onSelect function is not
 // called when the value is set manually via SetSelected.
 func (c *Checkbox) SetSelected(selected bool) {
@@ -86,6 +136,27 @@ func (c *Checkbox) SetSelected(selected bool) {
 	}
 	c.selected = selected
 	c.updateImage()
+	MarkDirty(c)
+}
+
+// Focus returns the focus state of the widget.
+func (c *Checkbox) Focus() bool {
+	return c.focus
+}
+
+// focusOnClick returns false, as the widget is only focused via focus
+// traversal. Clicking the widget leaves focus unchanged.
+func (c *Checkbox) focusOnClick() bool {
+	return false
+}
+
+// SetFocus sets the focus state of the widget.
+func (c *Checkbox) SetFocus(focus bool) (accept bool) {
+	if c.focus != focus {
+		c.focus = focus
+		c.updateImage()
+	}
+	return true
 }
 
 // Cursor returns the cursor shape shown when a mouse cursor hovers over the
@@ -94,8 +165,26 @@ func (c *Checkbox) Cursor() ebiten.CursorShapeType {
 	return ebiten.CursorShapePointer
 }
 
-// HandleKeyboard is called when a keyboard event occurs.
-func (c *Checkbox) HandleKeyboard(ebiten.Key, rune) (handled bool, err error) {
+// Accessibility returns the role, name, value and state of the widget.
+func (c *Checkbox) Accessibility() Accessibility {
+	return Accessibility{
+		Role:    RoleCheckbox,
+		Checked: c.selected,
+		Focused: c.focus,
+	}
+}
+
+// HandleKeyboard is called when a keyboard event occurs. The Checkbox is
+// toggled when a confirm key is pressed.
+func (c *Checkbox) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
+	if r != 0 {
+		return false, nil
+	}
+	for _, confirmKey := range Bindings.ConfirmKeyboard {
+		if key == confirmKey {
+			return true, c.toggle()
+		}
+	}
 	return false, nil
 }
 
@@ -104,19 +193,20 @@ func (c *Checkbox) HandleMouse(cursor image.Point, pressed bool, clicked bool) (
 	if !clicked {
 		return true, nil
 	}
+	return true, c.toggle()
+}
 
+func (c *Checkbox) toggle() error {
 	c.selected = !c.selected
 	c.updateImage()
 
 	c.Lock()
 	onSelect := c.onSelect
+	c.Unlock()
 	if onSelect == nil {
-		c.Unlock()
-		return true, nil
+		return nil
 	}
-	c.Unlock()
-
-	return true, onSelect()
+	return onSelect()
 }
 
 func (c *Checkbox) updateImage() {
@@ -143,7 +233,11 @@ func (c *Checkbox) updateImage() {
 	}
 
 	// Draw border.
-	c.img.Fill(c.borderColor)
+	borderColor := c.borderColor
+	if c.focus {
+		borderColor = c.borderFocused
+	}
+	c.img.Fill(borderColor)
 	c.img.SubImage(rectAtOrigin(r).Inset(c.borderSize)).(*ebiten.Image).Fill(color.RGBA64{0, 0, 0, 0})
 
 	// Draw check mark.
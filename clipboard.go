@@ -0,0 +1,153 @@
+package etk
+
+import (
+	"io"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Clipboard provides access to a clipboard. The system clipboard is used by
+// default. Applications may replace the clipboard via SetClipboard.
+type Clipboard interface {
+	// ReadText returns the text on the clipboard.
+	ReadText() ([]byte, error)
+
+	// WriteText replaces the text on the clipboard.
+	WriteText(text []byte) error
+}
+
+// MemoryClipboard is a clipboard which stores text in memory. Text copied to a
+// MemoryClipboard may only be pasted within the application. MemoryClipboard
+// is used in place of the system clipboard on platforms where the system
+// clipboard is not available.
+type MemoryClipboard struct {
+	text []byte
+	sync.Mutex
+}
+
+// ReadText returns the text on the clipboard.
+func (c *MemoryClipboard) ReadText() ([]byte, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	return append([]byte(nil), c.text...), nil
+}
+
+// WriteText replaces the text on the clipboard.
+func (c *MemoryClipboard) WriteText(text []byte) error {
+	c.Lock()
+	defer c.Unlock()
+
+	c.text = append(c.text[:0], text...)
+	return nil
+}
+
+// Copier may be implemented by widgets which copy text to the clipboard.
+// Pressing Ctrl+C while a Copier is focused, or while it owns the selection,
+// calls Copy. See TextSelector.
+type Copier interface {
+	// Copy copies text from the widget to the clipboard.
+	Copy() error
+}
+
+// Cutter may be implemented by widgets which cut text to the clipboard.
+// Pressing Ctrl+X while a Cutter is focused calls Cut.
+type Cutter interface {
+	// Cut copies text from the widget to the clipboard and removes it from
+	// the widget.
+	Cut() error
+}
+
+var (
+	activeClipboard Clipboard = newSystemClipboard()
+	clipboardLock   sync.Mutex
+)
+
+// ActiveClipboard returns the clipboard used by all widgets.
+func ActiveClipboard() Clipboard {
+	clipboardLock.Lock()
+	defer clipboardLock.Unlock()
+
+	return activeClipboard
+}
+
+// SetClipboard sets the clipboard used by all widgets. Setting a nil clipboard
+// restores the system clipboard.
+func SetClipboard(c Clipboard) {
+	if c == nil {
+		c = newSystemClipboard()
+	}
+
+	clipboardLock.Lock()
+	defer clipboardLock.Unlock()
+
+	activeClipboard = c
+}
+
+// ReadClipboard returns the text on the active clipboard.
+func ReadClipboard() ([]byte, error) {
+	return ActiveClipboard().ReadText()
+}
+
+// WriteClipboard replaces the text on the active clipboard.
+func WriteClipboard(text []byte) error {
+	return ActiveClipboard().WriteText(text)
+}
+
+// findCopier returns the provided widget, or the widget wrapped by it, as a
+// Copier.
+func findCopier(w Widget) Copier {
+	for w != nil {
+		copier, ok := w.(Copier)
+		if ok {
+			return copier
+		}
+		w = unwrap(w)
+	}
+	return nil
+}
+
+// handleClipboard copies, cuts or pastes text when key is C, X or V, Ctrl is
+// held and a widget which supports the action is focused. Text is copied
+// from the widget which owns the selection, when there is one.
+func (u *UI) handleClipboard(key ebiten.Key) (handled bool, err error) {
+	if !u.input.IsKeyPressed(ebiten.KeyControl) {
+		return false, nil
+	}
+	if key == ebiten.KeyC {
+		w := u.focusedWidget
+		if u.selectionOwner != nil {
+			w = u.selectionOwner
+		}
+		copier := findCopier(w)
+		if copier == nil {
+			return false, nil
+		}
+		return true, copier.Copy()
+	} else if u.focusedWidget == nil {
+		return false, nil
+	}
+	switch {
+	case key == ebiten.KeyX:
+		cutter, ok := u.focusedWidget.(Cutter)
+		if !ok {
+			return false, nil
+		}
+		MarkDirty(u.focusedWidget)
+		return true, cutter.Cut()
+	case key == ebiten.KeyV:
+		writer, ok := u.focusedWidget.(io.Writer)
+		if !ok {
+			return false, nil
+		}
+		text, err := ReadClipboard()
+		if err != nil {
+			return true, err
+		}
+		MarkDirty(u.focusedWidget)
+		_, err = writer.Write(text)
+		return true, err
+	}
+	return false, nil
+}
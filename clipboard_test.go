@@ -0,0 +1,65 @@
+package etk_test
+
+import (
+	"testing"
+
+	"codeberg.org/tslocum/etk"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestClipboard(t *testing.T) {
+	setupStyle(t)
+
+	clipboard := &etk.MemoryClipboard{}
+	etk.SetClipboard(clipboard)
+	defer etk.SetClipboard(nil)
+
+	a, b := etk.NewInput("Hello", nil, nil), etk.NewInput("", nil, nil)
+	g := etk.NewGrid()
+	g.AddChildAt(a, 0, 0, 1, 1)
+	g.AddChildAt(b, 1, 0, 1, 1)
+	u, f, _, _ := setupFakeInput(t, g)
+
+	press := func(key ebiten.Key) {
+		f.PressKey(ebiten.KeyControl)
+		f.PressKey(key)
+		frame(t, u, f)
+		f.ReleaseKey(key)
+		f.ReleaseKey(ebiten.KeyControl)
+		frame(t, u, f)
+	}
+
+	u.SetFocus(a)
+	press(ebiten.KeyC)
+	text, err := clipboard.ReadText()
+	if err != nil {
+		t.Fatal(err)
+	} else if string(text) != "Hello" || a.Text() != "Hello" {
+		t.Fatalf("unexpected copy result: clipboard %q, field %q", text, a.Text())
+	}
+
+	a.SetText("World")
+	press(ebiten.KeyX)
+	text, err = clipboard.ReadText()
+	if err != nil {
+		t.Fatal(err)
+	} else if string(text) != "World" || a.Text() != "" {
+		t.Fatalf("unexpected cut result: clipboard %q, field %q", text, a.Text())
+	}
+
+	u.SetFocus(b)
+	press(ebiten.KeyV)
+	if b.Text() != "World" {
+		t.Fatalf("unexpected paste result: expected World, got %q", b.Text())
+	}
+
+	b.SetMask('*')
+	b.SetText("secret")
+	press(ebiten.KeyC)
+	text, err = clipboard.ReadText()
+	if err != nil {
+		t.Fatal(err)
+	} else if string(text) != "World" {
+		t.Fatalf("masked text was copied")
+	}
+}
@@ -0,0 +1,233 @@
+package etk
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ContextMenu is a popup menu which lists actions. Context menus are usually
+// shown when a widget is right-clicked or long-pressed. See WithContextMenu.
+//
+// While shown, the context menu is the topmost modal widget. The menu is
+// closed when an action is selected, when the mouse is clicked or the screen
+// is touched outside of the menu, or when the escape key is pressed.
+type ContextMenu struct {
+	*Box
+	list    *List
+	labels  []string
+	actions []func()
+	point   image.Point
+	ui      *UI
+	focus   bool
+}
+
+// NewContextMenu returns a new ContextMenu widget.
+func NewContextMenu(itemHeight int) *ContextMenu {
+	m := &ContextMenu{
+		Box: NewBox(),
+	}
+	m.list = NewList(itemHeight, m.selectAction, m.confirmAction)
+	m.list.SetBackground(Style.ContextMenuBgColor)
+	m.list.SetDrawBorder(true)
+	m.list.SetSelectionMode(SelectRow)
+	SetTabIndex(m.list, -1)
+	m.Box.AddChild(m.list)
+	return m
+}
+
+// AddAction adds an action to the menu. The action is called after it is
+// selected and the menu is closed.
+func (m *ContextMenu) AddAction(label string, action func()) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.labels = append(m.labels, label)
+	m.actions = append(m.actions, action)
+
+	t := NewText(label)
+	t.SetVertical(AlignCenter)
+	t.SetForeground(Style.ContextMenuTextColor)
+	t.SetScrollBarVisible(false)
+	t.SetWordWrap(false)
+	m.list.AddChildAt(t, 0, len(m.labels)-1)
+}
+
+// Clear removes all actions from the menu.
+func (m *ContextMenu) Clear() {
+	m.Lock()
+	defer m.Unlock()
+
+	m.labels = nil
+	m.actions = nil
+	m.list.Clear()
+}
+
+// Show shows the menu at the provided screen location within the active UI.
+// The menu is moved as needed to keep it within the screen.
+func (m *ContextMenu) Show(p image.Point) {
+	m.Lock()
+	if m.ui != nil {
+		m.Unlock()
+		return
+	}
+	m.point = p
+	m.ui = activeUI()
+	m.Unlock()
+
+	m.list.Lock()
+	m.list.selectedY = -1
+	m.list.hoverY = -1
+	m.list.offset = 0
+	m.list.recreateGrid = true
+	m.list.Unlock()
+
+	m.ui.pushModal(m, false)
+}
+
+// Hide closes the menu.
+func (m *ContextMenu) Hide() {
+	m.Lock()
+	u := m.ui
+	m.ui = nil
+	m.Unlock()
+
+	if u != nil && u.Modal() == m {
+		u.PopModal()
+	}
+}
+
+// Shown returns whether the menu is shown.
+func (m *ContextMenu) Shown() bool {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.ui != nil
+}
+
+// SetRect sets the position and size of the widget. The menu is sized to fit
+// its actions and positioned within the provided area.
+func (m *ContextMenu) SetRect(r image.Rectangle) {
+	m.Lock()
+	m.rect = r
+	p := m.point
+	labels := m.labels
+	m.Unlock()
+
+	face := FontFace(Style.TextFont, Scale(Style.TextSize))
+	var width int
+	for _, label := range labels {
+		bounds := BoundString(face, label)
+		if bounds.Dx() > width {
+			width = bounds.Dx()
+		}
+	}
+	width += Scale(initialPadding)*2 + Scale(Style.ButtonBorderSize)*2
+	height := len(labels) * m.list.itemHeight
+	if width > r.Dx() {
+		width = r.Dx()
+	}
+	if height > r.Dy() {
+		height = r.Dy()
+	}
+
+	// Open the menu toward the bottom right of the point, flipping it when it
+	// would extend beyond the screen.
+	x, y := p.X, p.Y
+	if x+width > r.Max.X {
+		x = p.X - width
+	}
+	if y+height > r.Max.Y {
+		y = p.Y - height
+	}
+	x = max(r.Min.X, min(x, r.Max.X-width))
+	y = max(r.Min.Y, min(y, r.Max.Y-height))
+	m.list.SetRect(image.Rect(x, y, x+width, y+height))
+}
+
+// Focus returns the focus state of the widget.
+func (m *ContextMenu) Focus() bool {
+	return m.focus
+}
+
+// SetFocus sets the focus state of the widget.
+func (m *ContextMenu) SetFocus(focus bool) (accept bool) {
+	m.focus = focus
+	return true
+}
+
+// HandleKeyboard is called when a keyboard event occurs. The movement keys
+// change the highlighted action, a confirm key selects it and the escape key
+// closes the menu.
+func (m *ContextMenu) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
+	if r != 0 {
+		return true, nil
+	} else if key == ebiten.KeyEscape {
+		m.Hide()
+		return true, nil
+	}
+	_, err = m.list.HandleKeyboard(key, r)
+	return true, err
+}
+
+// HandleMouseEvent is called when a mouse event occurs. Mouse events over the
+// menu are handled by the menu's list, so any click received here is outside
+// of the menu and closes it.
+func (m *ContextMenu) HandleMouseEvent(e MouseEvent) (handled bool, err error) {
+	if e.Clicked {
+		m.Hide()
+	}
+	return true, nil
+}
+
+// selectAction is called when an action is clicked.
+func (m *ContextMenu) selectAction(index int) (accept bool) {
+	m.confirmAction(index)
+	return false
+}
+
+// confirmAction closes the menu and calls the action at the provided index.
+func (m *ContextMenu) confirmAction(index int) {
+	m.Lock()
+	if index < 0 || index >= len(m.actions) {
+		m.Unlock()
+		return
+	}
+	action := m.actions[index]
+	m.Unlock()
+
+	m.Hide()
+	if action != nil {
+		action()
+	}
+}
+
+// WithContextMenu wraps a widget to show a context menu when the widget is
+// clicked with a mouse button in Bindings.ContextMouse, or long-pressed on a
+// touch screen.
+type WithContextMenu struct {
+	Widget
+	Menu *ContextMenu
+}
+
+// HandleMouseEvent is called when a mouse event occurs.
+func (w *WithContextMenu) HandleMouseEvent(e MouseEvent) (handled bool, err error) {
+	if w.Menu != nil && (e.LongPress || (e.Clicked && !e.Touch && contextButton(e.Button))) {
+		w.Menu.Show(e.Cursor)
+		return true, nil
+	}
+	handled, err = handleMouseEvent(w.Widget, e)
+	// Handle touches so that long-presses are received.
+	return handled || (e.Touch && e.Clicked), err
+}
+
+// contextButton returns whether the provided button is in
+// Bindings.ContextMouse.
+func contextButton(button ebiten.MouseButton) bool {
+	for _, b := range Bindings.ContextMouse {
+		if button == b {
+			return true
+		}
+	}
+	return false
+}
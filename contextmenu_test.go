@@ -0,0 +1,123 @@
+package etk_test
+
+import (
+	"image"
+	"testing"
+
+	"codeberg.org/tslocum/etk"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type mouseEventWidget struct {
+	*testWidget
+	events []etk.MouseEvent
+}
+
+func (w *mouseEventWidget) HandleMouseEvent(e etk.MouseEvent) (handled bool, err error) {
+	if e.Clicked {
+		w.events = append(w.events, e)
+	}
+	return true, nil
+}
+
+func TestMouseEventButton(t *testing.T) {
+	a := newTestWidget()
+	w := &mouseEventWidget{testWidget: newTestWidget()}
+	g := etk.NewGrid()
+	g.AddChildAt(a, 0, 0, 1, 1)
+	g.AddChildAt(w, 1, 0, 1, 1)
+	u, f, _, _ := setupFakeInput(t, g)
+
+	for _, button := range []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonMiddle, ebiten.MouseButtonRight} {
+		for _, x := range []int{50, 150} {
+			f.MoveCursor(x, 50)
+			f.PressMouse(button)
+			frame(t, u, f)
+			f.ReleaseMouse(button)
+			frame(t, u, f)
+		}
+	}
+
+	if a.clicks != 1 {
+		t.Fatalf("unexpected clicks: expected 1, got %d", a.clicks)
+	} else if len(w.events) != 3 || w.events[0].Button != ebiten.MouseButtonLeft || w.events[1].Button != ebiten.MouseButtonMiddle || w.events[2].Button != ebiten.MouseButtonRight {
+		t.Fatalf("unexpected mouse events: %+v", w.events)
+	}
+}
+
+func TestMouseEventButtonRightClick(t *testing.T) {
+	setupStyle(t)
+
+	var pressed int
+	button := etk.NewButton("Button", func() error {
+		pressed++
+		return nil
+	})
+	u, f, _, _ := setupFakeInput(t, button)
+
+	// Right-clicking a button does not press it.
+	for _, b := range []ebiten.MouseButton{ebiten.MouseButtonRight, ebiten.MouseButtonLeft} {
+		f.MoveCursor(50, 50)
+		f.PressMouse(b)
+		frame(t, u, f)
+		f.ReleaseMouse(b)
+		frame(t, u, f)
+	}
+	if pressed != 1 {
+		t.Fatalf("unexpected button presses: expected 1 after right-click and left-click, got %d", pressed)
+	}
+}
+
+func TestContextMenu(t *testing.T) {
+	setupStyle(t)
+
+	var selected int
+	menu := etk.NewContextMenu(20)
+	menu.AddAction("First", func() { selected = 1 })
+	menu.AddAction("Second", func() { selected = 2 })
+	a, b := newTestWidget(), newTestWidget()
+	g := etk.NewGrid()
+	g.AddChildAt(&etk.WithContextMenu{Widget: a, Menu: menu}, 0, 0, 1, 1)
+	g.AddChildAt(b, 1, 0, 1, 1)
+	u, f, _, _ := setupFakeInput(t, g)
+
+	click := func(x int, y int, button ebiten.MouseButton) {
+		f.MoveCursor(x, y)
+		f.PressMouse(button)
+		frame(t, u, f)
+		f.ReleaseMouse(button)
+		frame(t, u, f)
+	}
+
+	// Open near the bottom right corner to test clamping.
+	click(95, 90, ebiten.MouseButtonRight)
+	if !menu.Shown() || u.Modal() != menu {
+		t.Fatalf("context menu was not shown")
+	} else if a.clicks != 0 {
+		t.Fatalf("right-click was passed to wrapped widget")
+	}
+	r := menu.Children()[0].Rect()
+	if !r.In(image.Rect(0, 0, 200, 100)) {
+		t.Fatalf("context menu is not within the screen: %s", r)
+	}
+
+	f.PressKey(ebiten.KeyEscape)
+	frame(t, u, f)
+	f.ReleaseKey(ebiten.KeyEscape)
+	if menu.Shown() || u.Modal() != nil {
+		t.Fatalf("context menu was not closed by escape key")
+	}
+
+	click(10, 10, ebiten.MouseButtonRight)
+	r = menu.Children()[0].Rect()
+	click(r.Min.X+5, r.Min.Y+30, ebiten.MouseButtonLeft)
+	if selected != 2 || menu.Shown() {
+		t.Fatalf("unexpected action: expected 2, got %d", selected)
+	}
+
+	click(10, 10, ebiten.MouseButtonRight)
+	click(150, 50, ebiten.MouseButtonLeft)
+	if menu.Shown() || b.clicks != 0 {
+		t.Fatalf("context menu was not closed by outside click")
+	}
+}
@@ -0,0 +1,255 @@
+package etk
+
+import (
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// dirtyLock is held while accessing the dirty widgets of a UI.
+var dirtyLock sync.Mutex
+
+// dirtyMarker is implemented by Box, and by each widget which embeds it, to
+// store whether the widget was marked dirty on the widget itself.
+type dirtyMarker interface {
+	markDirty()
+	isDirty() bool
+	clearDirty() bool
+}
+
+// MarkDirty marks a widget as changed, causing it to be redrawn. Widgets are
+// marked dirty automatically when they receive user input or focus, and when
+// the content of a built-in widget is changed via SetText, Write and similar
+// functions. Call MarkDirty after making any other change which affects the
+// appearance of a widget. See also UI.Dirty.
+//
+// Widgets which embed Box store whether they are dirty themselves. Other
+// widgets are marked dirty within the active UI until it is next drawn.
+func MarkDirty(w Widget) {
+	for wrapped := w; wrapped != nil; wrapped = unwrap(wrapped) {
+		marker, ok := wrapped.(dirtyMarker)
+		if ok {
+			marker.markDirty()
+			return
+		}
+	}
+	if w == nil {
+		return
+	}
+	u := activeUI()
+	dirtyLock.Lock()
+	if u.dirtyWidgets == nil {
+		u.dirtyWidgets = make(map[Widget]bool)
+	}
+	u.dirtyWidgets[w] = true
+	dirtyLock.Unlock()
+}
+
+// takeDirty returns whether the provided widget, or a widget wrapped by it,
+// was marked dirty since it was last drawn, and clears the mark.
+func (u *UI) takeDirty(w Widget) bool {
+	dirtyLock.Lock()
+	defer dirtyLock.Unlock()
+
+	var dirty bool
+	for ; w != nil; w = unwrap(w) {
+		marker, ok := w.(dirtyMarker)
+		if ok && marker.clearDirty() {
+			dirty = true
+		}
+		if u.dirtyWidgets[w] {
+			delete(u.dirtyWidgets, w)
+			dirty = true
+		}
+	}
+	return dirty
+}
+
+// clearDirtyWidgets forgets the widgets which do not embed Box and were
+// marked dirty but not drawn, such as widgets which were hidden or removed.
+func (u *UI) clearDirtyWidgets() {
+	dirtyLock.Lock()
+	defer dirtyLock.Unlock()
+
+	clear(u.dirtyWidgets)
+}
+
+// treeDirty returns whether a drawn widget within the provided widget tree was
+// marked dirty.
+func (u *UI) treeDirty(w Widget) bool {
+	if !u.drawn(w) {
+		return false
+	}
+	for wrapped := w; wrapped != nil; wrapped = unwrap(wrapped) {
+		marker, ok := wrapped.(dirtyMarker)
+		if ok && marker.isDirty() {
+			return true
+		}
+	}
+	for _, child := range w.Children() {
+		if u.treeDirty(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrap returns the widget wrapped by a built-in wrapper widget, or nil.
+func unwrap(w Widget) Widget {
+	switch wrapper := w.(type) {
+	case *WithoutFocus:
+		return wrapper.Widget
+	case *WithoutMouse:
+		return wrapper.Widget
+	case *WithoutMouseExceptScroll:
+		return wrapper.Widget
+	case *WithTooltip:
+		return wrapper.Widget
+	case *WithCache:
+		return wrapper.Widget
+	case *WithContextMenu:
+		return wrapper.Widget
+	case *WithAccessibleName:
+		return wrapper.Widget
+	case *leavingWidget:
+		return wrapper.Widget
+	}
+	return nil
+}
+
+// Dirty returns whether the default UI must be redrawn. See UI.Dirty.
+func Dirty() bool {
+	return activeUI().Dirty()
+}
+
+// Dirty returns whether the UI must be redrawn because it has changed since it
+// was last drawn. Applications which disable clearing the screen each frame via
+// ebiten.SetScreenClearedEveryFrame may skip calling Draw while Dirty returns
+// false, leaving the previously drawn frame on the screen. Only widgets which
+// are shown within the UI are considered.
+func (u *UI) Dirty() bool {
+	if u.dirty {
+		return true
+	}
+	dirtyLock.Lock()
+	marked := len(u.dirtyWidgets) != 0
+	dirtyLock.Unlock()
+	if marked || u.treeDirty(u.root) {
+		return true
+	}
+	for _, modals := range [2][]*modal{u.modals, u.closingModals} {
+		for _, m := range modals {
+			if u.treeDirty(m.w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// invalidate marks the UI as needing to be redrawn.
+func (u *UI) invalidate() {
+	u.dirty = true
+}
+
+// WithCache wraps a widget to draw it and its children to an offscreen image.
+// The image is drawn on the screen in place of the widget, and is only redrawn
+// after the widget or one of its children is marked dirty via MarkDirty, or
+// when the position, size or visibility of the widget or one of its children
+// changes. Wrap mostly static parts of the widget tree, such as menus, to
+// avoid redrawing them each frame.
+//
+// Caching is opt-in: etk does not cache subtrees which are not wrapped via
+// WithCache, as each cache holds an offscreen image the size of the widget.
+type WithCache struct {
+	Widget
+
+	image     *ebiten.Image
+	signature uint64
+	debug     bool
+
+	hash  uint64
+	dirty bool
+}
+
+const (
+	hashOffset = 14695981039346656037
+	hashPrime  = 1099511628211
+)
+
+// scanCache walks the widget tree, updating the signature of each cache
+// containing the widget, and marking those caches dirty when the widget was
+// marked dirty.
+func (u *UI) scanCache(w Widget, caches []*WithCache) {
+	if w == nil {
+		return
+	}
+	c, ok := w.(*WithCache)
+	if ok {
+		c.hash = hashOffset
+		caches = append(caches, c)
+	}
+
+	r := w.Rect()
+	visible := w.Visible()
+	children := w.Children()
+	values := [...]int{r.Min.X, r.Min.Y, r.Max.X, r.Max.Y, len(children)}
+	if !visible {
+		values[4] = -1
+	}
+	for _, c := range caches {
+		for _, v := range values {
+			c.hash = (c.hash ^ uint64(v)) * hashPrime
+		}
+	}
+	if u.takeDirty(w) {
+		for _, c := range caches {
+			c.dirty = true
+		}
+	}
+	if !visible {
+		return
+	}
+	for wrapped := w; wrapped != nil; wrapped = unwrap(wrapped) {
+		if wrapped == u.focusedWidget {
+			u.foundFocused = true
+		}
+	}
+	for _, child := range children {
+		u.scanCache(child, caches)
+	}
+}
+
+// drawCache draws a cached widget and its children to its offscreen image when
+// required, and then draws the offscreen image to the screen.
+func (u *UI) drawCache(c *WithCache, screen *ebiten.Image) error {
+	if u.cacheDepth == 0 {
+		u.scanCache(c, nil)
+	}
+	r := c.Rect()
+	if r.Empty() {
+		return nil
+	}
+	if c.image == nil || c.image.Bounds() != r {
+		if c.image != nil {
+			c.image.Deallocate()
+		}
+		c.image = ebiten.NewImageWithOptions(r, nil)
+		c.dirty = true
+	}
+	if c.dirty || c.hash != c.signature || c.debug != u.drawDebug {
+		c.image.Clear()
+		u.cacheDepth++
+		err := u.drawWidget(c, c.image)
+		u.cacheDepth--
+		if err != nil {
+			return err
+		}
+		c.signature, c.debug, c.dirty = c.hash, u.drawDebug, false
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+	screen.DrawImage(c.image, op)
+	return nil
+}
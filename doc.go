@@ -9,6 +9,7 @@ based on official widgets.
 The following official widgets are available:
   - [Box] - Building block for creating other widgets.
   - [Button] - Clickable button.
+  - [ContextMenu] - Popup menu listing actions, shown when a widget is right-clicked or long-pressed.
   - [FilePicker] - File and directory creation and selection dialog.
   - [Flex] - Flexible stack-based layout. Each Flex widget may be oriented horizontally or vertically.
   - [Frame] - Widget container. All child widgets are displayed at once. Child widgets are not repositioned by default.
@@ -19,6 +20,7 @@ The following official widgets are available:
   - [Select] - Dropdown selection widget.
   - [Sprite] - Resizable image.
   - [Text] - Text display widget.
+  - [TextArea] - Multi-line text editing widget with optional line numbers.
   - [Window] - Widget paging mechanism. Only one widget added to a window is displayed at a time.
 
 # Input Propagation
@@ -29,8 +31,36 @@ stack of widgets under the mouse.
 
 Clicking or tapping on a widget focuses the widget. This is handled by etk
 automatically when a widget returns a handled value of true.
+Buttons, checkboxes and selects are focused via focus traversal only.
+Clicking them leaves the focused widget focused, so that text may continue to
+be entered into an Input after clicking a Button.
 
-Keyboard events are passed to the focused widget.
+Mouse wheel and touchpad scrolling is passed to the topmost widget under the
+mouse which implements Scroller. When the widget is unable to scroll any
+further, the scroll continues to propagate to its parent widgets. Scroll speed
+is configured via Bindings.ScrollSpeed.
+
+Keyboard events are passed to the focused widget. Widgets which implement
+KeyEventHandler receive extended keyboard events, which include modifier key
+states, key releases and repeated key presses.
+
+Ancestors of the focused widget which implement KeyCapturer receive keyboard
+events before the focused widget. Keyboard events which are not handled by the
+focused widget bubble up to its ancestors which implement KeyBubbler, allowing
+container widgets to handle keys such as navigation between their children.
+
+Application-level keyboard shortcuts may be registered via AddShortcut. Each
+shortcut is checked either before the focused widget receives the key, or only
+when the focused widget does not handle the key.
+
+Call Consumed after Update to determine whether mouse, touch, keyboard and
+gamepad input was consumed by widgets. Input which was not consumed may be
+handled by the application.
+
+Widgets which implement Hoverer are notified when the mouse cursor enters and
+leaves them. Only the topmost widget under the cursor which handles mouse
+events is hovered. Button, Checkbox, Select and List highlight themselves
+while hovered using the hover colors in Style.
 
 # Focus Propagation
 
@@ -39,6 +69,25 @@ to be focused accepts this focus. If it does, the previously focused widget is
 un-focused. If the widget does not accept the focus, the previously focused
 widget remains focused.
 
+Pressing Tab focuses the next visible widget which accepts focus, and pressing
+Shift+Tab focuses the previous widget. Widgets are focused in the order they
+appear in the widget tree, starting from the root widget. This order may be
+overridden via SetTabIndex. The keys used to move focus are configured via
+Bindings. Focused widgets which implement FocusKeyCapturer, such as a TextArea
+with an indent set, may receive Tab themselves, in which case focus is moved
+by pressing Ctrl+Tab.
+
+Pressing a movement key or gamepad button first passes the corresponding key
+to the focused widget. When the focused widget does not handle the key, focus
+moves to the nearest widget in that direction. Pressing a confirm gamepad
+button passes the confirm key to the focused widget.
+
+# Input Sources
+
+User input is read from Ebitengine by default. Call SetInputSource to provide
+input from another source, such as a FakeInput, which allows tests to simulate
+mouse, touch, keyboard and gamepad input frame by frame.
+
 # Cursor Unification
 
 Input events generated by desktop mice and touch screens are unified in etk.
@@ -49,11 +98,125 @@ Clicked is true the first frame the mouse event or touch screen event is receive
 When the mouse click or touch screen tap is released, the widget that was originally
 clicked or tapped always receives a final event where clicked and pressed are both false.
 
+Only the mouse buttons in Bindings.ConfirmMouse are reported to HandleMouse.
+Bindings.ConfirmMouse contains only the left mouse button by default, as the
+right mouse button is reserved for context menus via Bindings.ContextMouse.
+Previous versions also confirmed with the right mouse button. Applications
+which rely on right-clicking to confirm may add ebiten.MouseButtonRight to
+Bindings.ConfirmMouse.
+Widgets which implement MouseEventHandler receive extended mouse events, which
+include the mouse button involved (including the middle button), modifier key
+states and touch screen long-presses.
+
+# Clipboard
+
+Pressing Ctrl+C, Ctrl+X or Ctrl+V copies, cuts or pastes text when the focused
+widget implements Copier, Cutter or io.Writer. Input supports copying, cutting
+and pasting, and Text supports copying via Text.Copy. The system clipboard is
+used by default, falling back to a MemoryClipboard on platforms without access
+to it. Applications may provide their own clipboard via SetClipboard.
+
+# Text Selection
+
+Text within Input widgets, and Text widgets which are made selectable via
+Text.SetSelectable, may be selected by clicking and dragging, double-clicking
+to select a word or triple-clicking to select a line. Text within an Input may
+also be selected by holding Shift while moving the caret.
+Widgets which implement TextSelector own the selection when they are clicked,
+and pressing Ctrl+C copies the selected text from the owner of the selection
+even when another widget is focused.
+
+# Undo and Redo
+
+Edits made within an Input may be undone by pressing Ctrl+Z and redone by
+pressing Ctrl+Y or Ctrl+Shift+Z. Typing is undone one word at a time, and
+consecutive deletions are undone together. The edit history is cleared when
+Input.SetText is called unless disabled via Input.SetClearHistoryOnSetText.
+
+# Input Methods
+
+Widgets which implement Composer accept text entered via an input method editor,
+such as when entering Chinese, Japanese or Korean text. While a Composer is
+focused, the input method editor is told where the insertion point is so that
+its candidate window is shown next to the widget, text being composed is shown
+underlined at the insertion point, and committed text is passed to the widget
+as runes. Input implements Composer.
+
+# Accessibility
+
+Widgets which implement Accessible report a role, name, value and state, such
+as whether a checkbox is checked or a list item is selected. AccessibilityTree
+walks the root widget and any modal widgets and returns the visible accessible
+widgets as a tree, allowing assistive technology bridges and automated tests to
+query the user interface semantically. Button, Checkbox, Input, List, Select,
+Text and Window implement Accessible. Any widget may be given an accessible name
+via WithAccessibleName.
+
+# Tooltips
+
+Widgets which implement Tooltipper show tooltip text when the mouse cursor
+hovers over them, or when they are long-pressed on a touch screen. Any widget
+may be wrapped via WithTooltip to show tooltip text.
+
+# Context Menus
+
+Any widget may be wrapped via WithContextMenu to show a ContextMenu when the
+widget is clicked with a mouse button in Bindings.ContextMouse, or when it is
+long-pressed on a touch screen. The menu is shown as a modal widget without a
+backdrop, and is positioned within the screen.
+
+# Drag and Drop
+
+Widgets which implement DragSource may be dragged by clicking or tapping them
+and moving the cursor at least Bindings.DragThreshold pixels. While dragging,
+mouse input is not passed to widgets, and the preview image is drawn at the
+cursor. Widgets which implement DropTarget are notified when a payload is
+dragged onto, over and off of them, and may accept or reject dropped payloads.
+List items may be reordered by dragging when List.SetReorderFunc is called.
+
+# Animation
+
+Animations started via Animate are advanced each time the UI is updated, with
+an Easing function applied to their progress. AnimateRect, AnimateColor,
+AnimateAlpha and AnimateScroll animate the position and size of widgets, colors,
+the opacity of widgets and the scroll offset of lists. The Select dropdown menu,
+the pages of a Window and modal widgets fade or slide in and out using the
+transitions configured in Style, which may be changed per widget via
+SetTransition. Call SetReducedMotion(true) to disable all animations and
+transitions, such as for users who prefer reduced motion.
+
 # Draw Order
 
 Each time etk draws a widget it subsequently draws all of the widget's children
 in the order they are returned.
 
+# Retained Rendering
+
+Widgets are drawn each frame unless they are wrapped via WithCache, which
+draws a widget and its children to an offscreen image. The image is only
+redrawn after the widget or one of its children changes. Caching is opt-in:
+wrap mostly static parts of the widget tree, such as menus, to avoid redrawing
+them each frame. Widgets are marked as changed automatically when they receive
+user input or focus, and when the content of a built-in widget is set. Call
+MarkDirty after making any other change which affects the appearance of a
+widget. Applications may skip drawing frames while Dirty returns false. Each
+UI tracks its own widgets, so changing a widget in one UI does not cause
+another UI to be redrawn.
+
+# Modal Widgets
+
+Modal widgets are shown above the root widget via PushModal and removed via
+PopModal. While a modal widget is shown, user input is passed only to the
+topmost modal widget and focus is kept within it.
+
+# Multiple User Interfaces
+
+The package-level functions Layout, Update, Draw and SetRoot operate on a
+default UI. Additional independent user interfaces may be created via NewUI,
+each with its own root widget, focused widget, modal widgets, shortcuts and
+input source. While a UI is being laid out, updated or drawn, package-level
+functions called by its widgets operate on that UI.
+
 # Environment Variables
 
 Set ETK_SCALE to a positive number to override the device scale factor. Applications
@@ -0,0 +1,193 @@
+package etk
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DragSource may be implemented by widgets which may be dragged. Dragging
+// begins when the widget is clicked or tapped and the cursor is then moved
+// at least Bindings.DragThreshold pixels while pressed.
+type DragSource interface {
+	// DragStart is called when the widget begins to be dragged. The point
+	// where the widget was originally clicked or tapped is provided. The
+	// payload is passed to drop targets. The preview image, which may be nil,
+	// is drawn at the cursor while dragging. When the payload is nil, the
+	// widget is not dragged.
+	DragStart(cursor image.Point) (payload any, preview *ebiten.Image)
+
+	// DragEnd is called after the payload is dropped. Accepted is true when
+	// a drop target accepted the payload.
+	DragEnd(payload any, accepted bool) error
+}
+
+// DropTarget may be implemented by widgets which accept dragged payloads.
+type DropTarget interface {
+	// DragEnter is called when a payload is dragged onto the widget.
+	DragEnter(payload any, cursor image.Point)
+
+	// DragOver is called each frame a payload is dragged over the widget,
+	// after DragEnter is called.
+	DragOver(payload any, cursor image.Point)
+
+	// DragLeave is called when a payload is dragged off of the widget.
+	DragLeave(payload any)
+
+	// Drop is called when a payload is dropped onto the widget. The widget
+	// returns whether the payload was accepted.
+	Drop(payload any, cursor image.Point) (accept bool, err error)
+}
+
+type dragState struct {
+	candidate DragSource
+	origin    image.Point
+
+	source  DragSource
+	payload any
+	preview *ebiten.Image
+	target  DropTarget
+	cursor  image.Point
+}
+
+// Dragging returns whether a payload is being dragged within the default UI.
+// See UI.Dragging.
+func Dragging() bool {
+	return activeUI().Dragging()
+}
+
+// Dragging returns whether a payload is currently being dragged.
+func (u *UI) Dragging() bool {
+	return u.drag.source != nil
+}
+
+// dragSourceAt returns the topmost drag source at the provided screen location.
+func dragSourceAt(w Widget, p image.Point) DragSource {
+	if w == nil || !w.Visible() {
+		return nil
+	}
+	children := w.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		result := dragSourceAt(children[i], p)
+		if result != nil {
+			return result
+		}
+	}
+	source, ok := w.(DragSource)
+	if !ok || !p.In(w.Rect()) {
+		return nil
+	}
+	return source
+}
+
+// dropTargetAt returns the topmost drop target at the provided screen location.
+func dropTargetAt(w Widget, p image.Point) DropTarget {
+	if w == nil || !w.Visible() {
+		return nil
+	}
+	children := w.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		result := dropTargetAt(children[i], p)
+		if result != nil {
+			return result
+		}
+	}
+	target, ok := w.(DropTarget)
+	if !ok || !p.In(w.Rect()) {
+		return nil
+	}
+	return target
+}
+
+// handleDrag starts, updates and ends dragging. While a payload is being
+// dragged, mouse events are not passed to widgets.
+func (u *UI) handleDrag(cursor image.Point, pressed bool, clicked bool) (dragging bool, err error) {
+	d := &u.drag
+	if d.source == nil {
+		if clicked {
+			d.candidate = dragSourceAt(u.activeRoot(), cursor)
+			d.origin = cursor
+			return false, nil
+		} else if d.candidate == nil {
+			return false, nil
+		} else if !pressed {
+			d.candidate = nil
+			return false, nil
+		}
+
+		threshold := Scale(Bindings.DragThreshold)
+		delta := cursor.Sub(d.origin)
+		if delta.X < threshold && delta.X > -threshold && delta.Y < threshold && delta.Y > -threshold {
+			return false, nil
+		}
+
+		source := d.candidate
+		d.candidate = nil
+		payload, preview := source.DragStart(d.origin)
+		if payload == nil {
+			return false, nil
+		}
+		d.source, d.payload, d.preview = source, payload, preview
+
+		// Release the pressed widget.
+		if u.pressedWidget != nil {
+			_, err := handleMouseEvent(u.pressedWidget, MouseEvent{Cursor: cursor, Button: u.heldButton})
+			if err != nil {
+				return true, err
+			}
+			u.pressedWidget = nil
+		}
+	}
+	d.cursor = cursor
+
+	target := dropTargetAt(u.activeRoot(), cursor)
+	if target != d.target {
+		if d.target != nil {
+			d.target.DragLeave(d.payload)
+		}
+		d.target = target
+		if target != nil {
+			target.DragEnter(d.payload, cursor)
+		}
+	} else if target != nil {
+		target.DragOver(d.payload, cursor)
+	}
+	if pressed {
+		return true, nil
+	}
+
+	// Drop payload.
+	source, payload := d.source, d.payload
+	target = d.target
+	d.source, d.payload, d.preview, d.target = nil, nil, nil, nil
+
+	var accepted bool
+	if target != nil {
+		accepted, err = target.Drop(payload, cursor)
+		if err != nil {
+			return true, err
+		}
+		w, ok := target.(Widget)
+		if ok {
+			MarkDirty(w)
+		}
+	}
+	w, ok := source.(Widget)
+	if ok {
+		MarkDirty(w)
+	}
+	return true, source.DragEnd(payload, accepted)
+}
+
+// drawDrag draws the preview image of the dragged payload at the cursor.
+func (u *UI) drawDrag(screen *ebiten.Image) {
+	d := &u.drag
+	if d.source == nil || d.preview == nil {
+		return
+	}
+	bounds := d.preview.Bounds()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(d.cursor.X-bounds.Dx()/2), float64(d.cursor.Y-bounds.Dy()/2))
+	op.ColorScale.ScaleAlpha(0.75)
+	screen.DrawImage(d.preview, op)
+}
@@ -0,0 +1,417 @@
+package etk
+
+import (
+	"image"
+	"image/color"
+
+	"codeberg.org/tslocum/etk/messeji"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// editField holds the state and methods shared by the Input and TextArea
+// widgets, which wrap an editable messeji.InputField within a border. Each
+// widget declares its own exported methods, which call the methods of
+// editField.
+type editField struct {
+	box             *Box
+	field           *messeji.InputField
+	onChange        func(text string, r rune) (accept bool)
+	borderSize      int
+	borderFocused   color.RGBA
+	borderUnfocused color.RGBA
+	focused         bool
+}
+
+// newEditField returns a new editField containing a field configured with
+// the default style. The box is locked while the field is modified, and is
+// embedded by the widget.
+func newEditField(box *Box, text string, onChange func(text string, r rune) (accept bool)) editField {
+	f := messeji.NewInputField(Style.TextFont, Scale(Style.TextSize), fontMutex)
+	f.SetForegroundColor(Style.TextColorLight)
+	f.SetBackgroundColor(transparent)
+	f.SetScrollBarColors(Style.ScrollAreaColor, Style.ScrollHandleColor)
+	f.SetScrollBorderSize(Scale(Style.ScrollBorderSize))
+	f.SetScrollBorderColors(Style.ScrollBorderTop, Style.ScrollBorderRight, Style.ScrollBorderBottom, Style.ScrollBorderLeft)
+	f.SetPrefix("")
+	f.SetSuffix("")
+	f.SetText(text)
+	f.SetHandleKeyboard(true)
+	f.SetCaretVisible(false)
+	f.SetSelectionColor(Style.TextSelectionColor)
+
+	e := editField{
+		box:             box,
+		field:           f,
+		onChange:        onChange,
+		borderSize:      Scale(Style.InputBorderSize),
+		borderFocused:   Style.InputBorderFocused,
+		borderUnfocused: Style.InputBorderUnfocused,
+	}
+	box.SetBackground(Style.InputBgColor)
+	return e
+}
+
+// editFunc returns the handler passed to the field's SetEditFunc, which calls
+// the change handler of the widget when one is set.
+func (e *editField) editFunc() func(text string, r rune) (accept bool) {
+	return func(text string, r rune) (accept bool) {
+		if e.onChange != nil {
+			return e.onChange(text, r)
+		}
+		return true
+	}
+}
+
+// messejiModifiers returns the modifier keys held during a KeyEvent.
+func messejiModifiers(e KeyEvent) messeji.Modifier {
+	var mods messeji.Modifier
+	if e.Shift {
+		mods |= messeji.ModifierShift
+	}
+	if e.Ctrl {
+		mods |= messeji.ModifierControl
+	}
+	if e.Alt {
+		mods |= messeji.ModifierAlt
+	}
+	if e.Meta {
+		mods |= messeji.ModifierMeta
+	}
+	return mods
+}
+
+// setRect sets the position and size of the widget.
+func (e *editField) setRect(r image.Rectangle) {
+	e.box.rect = r
+
+	e.field.SetRect(r)
+
+	for _, w := range e.box.children {
+		w.SetRect(r)
+	}
+}
+
+// setBorderSize sets the size of the border around the field.
+func (e *editField) setBorderSize(size int) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.borderSize = size
+}
+
+// setBorderColors sets the border colors of the field when focused and
+// unfocused.
+func (e *editField) setBorderColors(focused color.RGBA, unfocused color.RGBA) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.borderFocused = focused
+	e.borderUnfocused = unfocused
+}
+
+// foreground return the color of the text within the field.
+func (e *editField) foreground() color.RGBA {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	return e.field.ForegroundColor()
+}
+
+// setForeground sets the color of the text within the field.
+func (e *editField) setForeground(c color.RGBA) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetForegroundColor(c)
+}
+
+// focus returns the focus state of the widget.
+func (e *editField) focus() bool {
+	return e.focused
+}
+
+// caret returns the position of the insertion point, in bytes from the start
+// of the text.
+func (e *editField) caret() int {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	return e.field.Caret()
+}
+
+// setCaret sets the position of the insertion point, in bytes from the start
+// of the text.
+func (e *editField) setCaret(pos int) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetCaret(pos)
+	MarkDirty(e.box)
+}
+
+// text returns the content of the text buffer.
+func (e *editField) text() string {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	return e.field.Text()
+}
+
+// setText sets the text in the field.
+func (e *editField) setText(text string) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetText(text)
+	MarkDirty(e.box)
+}
+
+// undo undoes the last edit.
+func (e *editField) undo() bool {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	undone := e.field.Undo()
+	if undone {
+		MarkDirty(e.box)
+	}
+	return undone
+}
+
+// redo redoes the last edit which was undone.
+func (e *editField) redo() bool {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	redone := e.field.Redo()
+	if redone {
+		MarkDirty(e.box)
+	}
+	return redone
+}
+
+// setHistorySize sets the maximum number of edits which may be undone.
+func (e *editField) setHistorySize(size int) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetHistorySize(size)
+}
+
+// setClearHistoryOnSetText sets whether the edit history is cleared when the
+// text is set via SetText.
+func (e *editField) setClearHistoryOnSetText(clear bool) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetClearHistoryOnSetText(clear)
+}
+
+// clearHistory clears the edit history.
+func (e *editField) clearHistory() {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.ClearHistory()
+}
+
+// setScrollBarWidth sets the width of the scroll bar.
+func (e *editField) setScrollBarWidth(width int) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetScrollBarWidth(width)
+}
+
+// setScrollBarColors sets the color of the scroll bar area and handle.
+func (e *editField) setScrollBarColors(area color.RGBA, handle color.RGBA) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetScrollBarColors(area, handle)
+}
+
+// setScrollBarVisible sets whether the scroll bar is visible on the screen.
+func (e *editField) setScrollBarVisible(scrollVisible bool) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetScrollBarVisible(scrollVisible)
+}
+
+// setAutoHideScrollBar sets whether the scroll bar is automatically hidden
+// when the entire text buffer is visible.
+func (e *editField) setAutoHideScrollBar(autoHide bool) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetAutoHideScrollBar(autoHide)
+}
+
+// setFont sets the font and text size of the field.
+func (e *editField) setFont(fnt *text.GoTextFaceSource, size int) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetFont(fnt, size, fontMutex)
+}
+
+// padding returns the amount of padding around the text within the field.
+func (e *editField) padding() int {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	return e.field.Padding()
+}
+
+// setPadding sets the amount of padding around the text within the field.
+func (e *editField) setPadding(padding int) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetPadding(padding)
+}
+
+// setWordWrap sets a flag which, when enabled, causes text to wrap without
+// breaking words.
+func (e *editField) setWordWrap(wrap bool) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetWordWrap(wrap)
+}
+
+// setChangeFunc sets the handler called when the text changes.
+func (e *editField) setChangeFunc(onChange func(text string, r rune) (accept bool)) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.onChange = onChange
+}
+
+// cursorShape returns the cursor shape shown when a mouse cursor hovers over
+// the widget, or -1 to let widgets beneath determine the cursor shape.
+func (e *editField) cursorShape() ebiten.CursorShapeType {
+	return ebiten.CursorShapeText
+}
+
+// selection returns the bounds of the selected text, in bytes from the start
+// of the text.
+func (e *editField) selection() (start int, end int) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	return e.field.Selection()
+}
+
+// setSelection selects the text between the provided positions, in bytes from
+// the start of the text, and moves the caret to end.
+func (e *editField) setSelection(start int, end int) {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetSelection(start, end)
+	MarkDirty(e.box)
+}
+
+// selectedText returns the selected text.
+func (e *editField) selectedText() string {
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	return e.field.SelectedText()
+}
+
+// cut copies the text between start and end to the clipboard and removes it
+// from the field, unless the change handler rejects the change.
+func (e *editField) cut(text string, start int, end int) error {
+	e.box.Lock()
+	onChange := e.onChange
+	e.box.Unlock()
+
+	if start == end {
+		return nil
+	} else if onChange != nil && !onChange(text[:start]+text[end:], 0) {
+		return nil
+	}
+	err := WriteClipboard([]byte(text[start:end]))
+	if err != nil {
+		return err
+	}
+
+	e.box.Lock()
+	defer e.box.Unlock()
+
+	e.field.SetSelection(start, end)
+	e.field.Write(nil)
+	MarkDirty(e.box)
+	return nil
+}
+
+// write writes to the text buffer at the insertion point, replacing the
+// selected text.
+func (e *editField) write(p []byte) (n int, err error) {
+	MarkDirty(e.box)
+	return e.field.Write(p)
+}
+
+// caretBounds returns the location of the insertion point on the screen.
+func (e *editField) caretBounds() image.Rectangle {
+	return e.field.CaretRect()
+}
+
+// setComposition sets the text being composed via an input method editor.
+func (e *editField) setComposition(text string) {
+	e.field.SetComposition(text)
+	MarkDirty(e.box)
+}
+
+// handleKeyboard is called when a keyboard event occurs.
+func (e *editField) handleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
+	if !e.focused {
+		return false, nil
+	}
+
+	return e.field.HandleKeyEvent(key, r, messejiModifiers(activeUI().newKeyEvent(key, r)))
+}
+
+// handleKeyEvent is called when a key is pressed or released, or a rune is
+// entered.
+func (e *editField) handleKeyEvent(event KeyEvent) (handled bool, err error) {
+	if !e.focused || !event.Pressed {
+		return false, nil
+	}
+
+	return e.field.HandleKeyEvent(event.Key, event.Rune, messejiModifiers(event))
+}
+
+// handleMouse is called when a mouse event occurs.
+func (e *editField) handleMouse(cursor image.Point, pressed bool, clicked bool) (handled bool, err error) {
+	return e.field.HandleMouseEvent(cursor, pressed, clicked)
+}
+
+// handleScroll is called when the mouse wheel is scrolled over the widget.
+func (e *editField) handleScroll(x float64, y float64) (handled bool, err error) {
+	return e.field.HandleScrollEvent(x, y)
+}
+
+// draw draws the widget on the screen.
+func (e *editField) draw(screen *ebiten.Image) error {
+	e.field.Draw(screen)
+
+	// Draw border.
+	if e.borderSize == 0 {
+		return nil
+	}
+	r := e.box.rect
+	c := e.borderUnfocused
+	if e.focused {
+		c = e.borderFocused
+	}
+	screen.SubImage(image.Rect(r.Min.X, r.Min.Y, r.Min.X+e.borderSize, r.Max.Y)).(*ebiten.Image).Fill(c)
+	screen.SubImage(image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+e.borderSize)).(*ebiten.Image).Fill(c)
+	screen.SubImage(image.Rect(r.Max.X-e.borderSize, r.Min.Y, r.Max.X, r.Max.Y)).(*ebiten.Image).Fill(c)
+	screen.SubImage(image.Rect(r.Min.X, r.Max.Y-e.borderSize, r.Max.X, r.Max.Y)).(*ebiten.Image).Fill(c)
+	return nil
+}
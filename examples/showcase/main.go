@@ -96,6 +96,7 @@ func main() {
 	addExample(newSelectExample)
 	addExample(newSpriteExample)
 	addExample(newTextExample)
+	addExample(newTextAreaExample)
 	addExample(newWindowExample)
 
 	w.Show(0)
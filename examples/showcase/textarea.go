@@ -0,0 +1,16 @@
+//go:build example
+
+package main
+
+import (
+	"codeberg.org/tslocum/etk"
+)
+
+func newTextAreaExample() (string, etk.Widget, etk.Widget) {
+	area := etk.NewTextArea("func main() {\n\tfmt.Println(\"Hello, world!\")\n}\n", nil)
+	area.SetPadding(etk.Scale(10))
+	area.SetLineNumbers(true)
+	area.SetIndent("\t")
+
+	return "textarea", area, area
+}
@@ -0,0 +1,301 @@
+package etk
+
+import (
+	"image"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// tabIndexer is implemented by Box, and by each widget which embeds it, to
+// store the tab index of the widget on the widget itself.
+type tabIndexer interface {
+	tabIndex() int
+	setTabIndex(index int)
+}
+
+// findTabIndexer returns the provided widget, or the widget wrapped by it, as
+// a tabIndexer.
+func findTabIndexer(w Widget) tabIndexer {
+	for w != nil {
+		indexer, ok := w.(tabIndexer)
+		if ok {
+			return indexer
+		}
+		w = unwrap(w)
+	}
+	return nil
+}
+
+// clickFocuser is implemented by widgets which accept focus via focus
+// traversal, but which do not take focus away from the focused widget when
+// clicked, such as buttons.
+type clickFocuser interface {
+	focusOnClick() bool
+}
+
+// focusOnClick returns whether the provided widget, or the widget wrapped by
+// it, is focused when clicked.
+func focusOnClick(w Widget) bool {
+	for w != nil {
+		focuser, ok := w.(clickFocuser)
+		if ok {
+			return focuser.focusOnClick()
+		}
+		w = unwrap(w)
+	}
+	return true
+}
+
+// TabIndex returns the tab index of the provided widget. See SetTabIndex.
+func TabIndex(w Widget) int {
+	indexer := findTabIndexer(w)
+	if indexer == nil {
+		return 0
+	}
+	return indexer.tabIndex()
+}
+
+// SetTabIndex sets the position of a widget within the focus chain. Widgets
+// with a tab index of 0 (the default) are focused in the order they appear in
+// the widget tree. Widgets with a positive tab index are focused before all
+// other widgets, in ascending order. Widgets with a negative tab index are
+// skipped when moving focus via the keyboard, but may still be focused by
+// clicking or by calling SetFocus. The tab index is stored on the widget, so
+// only widgets which embed Box, and List, may be assigned a tab index.
+func SetTabIndex(w Widget, index int) {
+	indexer := findTabIndexer(w)
+	if indexer != nil {
+		indexer.setTabIndex(index)
+	}
+}
+
+// FocusNext focuses the next widget within the default UI. See UI.FocusNext.
+func FocusNext() {
+	activeUI().FocusNext()
+}
+
+// FocusNext focuses the next widget in the focus chain which accepts focus.
+// The focus chain wraps around after the last widget. See SetTabIndex.
+func (u *UI) FocusNext() {
+	u.moveFocus(1)
+}
+
+// FocusPrevious focuses the previous widget within the default UI. See
+// UI.FocusPrevious.
+func FocusPrevious() {
+	activeUI().FocusPrevious()
+}
+
+// FocusPrevious focuses the previous widget in the focus chain which accepts
+// focus. The focus chain wraps around before the first widget. See SetTabIndex.
+func (u *UI) FocusPrevious() {
+	u.moveFocus(-1)
+}
+
+func (u *UI) moveFocus(direction int) {
+	focusChain := appendFocusChain(u.focusChain[:0], u.activeRoot())
+	u.focusChain = focusChain
+	l := len(focusChain)
+	if l == 0 {
+		return
+	}
+	sort.SliceStable(focusChain, func(i, j int) bool {
+		a, b := TabIndex(focusChain[i]), TabIndex(focusChain[j])
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+
+	start := -1
+	for i, w := range focusChain {
+		if w == u.focusedWidget {
+			start = i
+			break
+		}
+	}
+	if start == -1 && direction < 0 {
+		start = l
+	}
+
+	original := u.focusedWidget
+	for i := 1; i <= l; i++ {
+		index := ((start+direction*i)%l + l) % l
+		u.SetFocus(focusChain[index])
+		if u.focusedWidget != original {
+			return
+		}
+	}
+}
+
+// appendFocusChain appends the visible widgets within the provided widget
+// tree which may receive focus via the keyboard.
+func appendFocusChain(chain []Widget, w Widget) []Widget {
+	if w == nil || !w.Visible() {
+		return chain
+	}
+	if TabIndex(w) >= 0 {
+		chain = append(chain, w)
+	}
+	for _, child := range w.Children() {
+		chain = appendFocusChain(chain, child)
+	}
+	return chain
+}
+
+// FocusKeyCapturer may be implemented by widgets which handle the focus keys
+// themselves while they are focused, such as a TextArea which inserts an
+// indent when Tab is pressed. Focus is still moved when a focus key is pressed
+// while holding Ctrl.
+type FocusKeyCapturer interface {
+	// CaptureFocusKeys returns whether the focus keys are passed to the
+	// widget instead of moving focus.
+	CaptureFocusKeys() bool
+}
+
+// findFocusKeyCapturer returns the provided widget, or the widget wrapped by
+// it, as a FocusKeyCapturer.
+func findFocusKeyCapturer(w Widget) FocusKeyCapturer {
+	for w != nil {
+		capturer, ok := w.(FocusKeyCapturer)
+		if ok {
+			return capturer
+		}
+		w = unwrap(w)
+	}
+	return nil
+}
+
+// handleFocusKey moves focus when one of the focus keys is pressed. Holding
+// shift while pressing a focus key moves focus to the previous widget.
+func (u *UI) handleFocusKey(key ebiten.Key) bool {
+	for _, focusKey := range Bindings.FocusNextKeyboard {
+		if key == focusKey {
+			capturer := findFocusKeyCapturer(u.focusedWidget)
+			if capturer != nil && capturer.CaptureFocusKeys() && !u.input.IsKeyPressed(ebiten.KeyControl) {
+				return false
+			}
+			if u.input.IsKeyPressed(ebiten.KeyShift) {
+				u.FocusPrevious()
+			} else {
+				u.FocusNext()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Direction represents a spatial direction.
+type Direction int
+
+// Directions.
+const (
+	DirectionLeft Direction = iota
+	DirectionRight
+	DirectionUp
+	DirectionDown
+)
+
+// FocusDirection focuses the nearest widget in the provided direction within
+// the default UI. See UI.FocusDirection.
+func FocusDirection(d Direction) {
+	activeUI().FocusDirection(d)
+}
+
+// FocusDirection focuses the nearest visible widget in the provided direction
+// which accepts focus. Distance is measured between the centers of the focused
+// widget and each candidate widget. When no widget is focused, the first
+// widget in the focus chain is focused instead.
+func (u *UI) FocusDirection(d Direction) {
+	if u.focusedWidget == nil {
+		u.FocusNext()
+		return
+	}
+
+	origin := rectCenter(u.focusedWidget.Rect())
+	type candidate struct {
+		w     Widget
+		score int
+	}
+	var candidates []candidate
+	u.focusChain = appendFocusChain(u.focusChain[:0], u.activeRoot())
+	for _, w := range u.focusChain {
+		if w == u.focusedWidget {
+			continue
+		}
+		p := rectCenter(w.Rect())
+		var primary, secondary int
+		switch d {
+		case DirectionLeft:
+			primary, secondary = origin.X-p.X, p.Y-origin.Y
+		case DirectionRight:
+			primary, secondary = p.X-origin.X, p.Y-origin.Y
+		case DirectionUp:
+			primary, secondary = origin.Y-p.Y, p.X-origin.X
+		case DirectionDown:
+			primary, secondary = p.Y-origin.Y, p.X-origin.X
+		}
+		if primary <= 0 {
+			continue
+		} else if secondary < 0 {
+			secondary = -secondary
+		}
+		candidates = append(candidates, candidate{w, primary + secondary*2})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score < candidates[j].score
+	})
+
+	original := u.focusedWidget
+	for _, c := range candidates {
+		u.SetFocus(c.w)
+		if u.focusedWidget != original {
+			return
+		}
+	}
+}
+
+func rectCenter(r image.Rectangle) image.Point {
+	return image.Point{r.Min.X + r.Dx()/2, r.Min.Y + r.Dy()/2}
+}
+
+// directionKey returns the direction of the provided key, if it is a movement key.
+func directionKey(key ebiten.Key) (d Direction, ok bool) {
+	for _, k := range Bindings.MoveLeftKeyboard {
+		if key == k {
+			return DirectionLeft, true
+		}
+	}
+	for _, k := range Bindings.MoveRightKeyboard {
+		if key == k {
+			return DirectionRight, true
+		}
+	}
+	for _, k := range Bindings.MoveUpKeyboard {
+		if key == k {
+			return DirectionUp, true
+		}
+	}
+	for _, k := range Bindings.MoveDownKeyboard {
+		if key == k {
+			return DirectionDown, true
+		}
+	}
+	return 0, false
+}
+
+// handleDirection passes a movement key event to the focused widget and its
+// ancestors. When the event is not handled, focus is moved in that direction.
+func (u *UI) handleDirection(d Direction, e KeyEvent) (handled bool, err error) {
+	if u.focusedWidget != nil {
+		handled, err := u.dispatchKeyEvent(e)
+		if err != nil || handled {
+			return handled, err
+		}
+	}
+	original := u.focusedWidget
+	u.FocusDirection(d)
+	return u.focusedWidget != original, nil
+}
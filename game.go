@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"image"
 	"image/color"
-	"io"
 	"log"
 	"os"
 	"strconv"
@@ -14,7 +13,6 @@ import (
 
 	"codeberg.org/tslocum/etk/messeji"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"golang.org/x/image/font"
 	"golang.org/x/image/math/fixed"
@@ -38,53 +36,12 @@ const (
 // This setting can greatly improve performance when resizing the window.
 var ResizeDebounce = 250 * time.Millisecond
 
-var root Widget
-
-var drawDebug bool
-
 var (
-	lastWidth, lastHeight int
-
-	lastX, lastY             int
-	lastCursorX, lastCursorY int
-
-	touchIDs      []ebiten.TouchID
-	activeTouchID = ebiten.TouchID(-1)
-
-	focusedWidget Widget
-
-	pressedWidget Widget
-
-	cursorShape ebiten.CursorShapeType
-
-	foundFocused bool
-
-	lastResize time.Time
-
-	lastBackspaceRepeat time.Time
-
-	keyBuffer  []ebiten.Key
-	runeBuffer []rune
-
 	fontMutex = &sync.Mutex{}
 )
 
-const maxScroll = 3
-
 var debugColor = color.RGBA{0, 0, 255, 255}
 
-const (
-	backspaceRepeatWait = 500 * time.Millisecond
-	backspaceRepeatTime = 75 * time.Millisecond
-)
-
-func init() {
-	envDebug := os.Getenv("ETK_DEBUG")
-	if strings.TrimSpace(envDebug) == "1" {
-		SetDebug(true)
-	}
-}
-
 var deviceScale float64
 
 // ScaleFactor returns the device scale factor. When running on Android, this function
@@ -138,37 +95,69 @@ func FontFace(source *text.GoTextFaceSource, size int) *text.GoTextFace {
 	}
 }
 
+// Root returns the root widget of the default UI. See UI.Root.
+func Root() Widget {
+	return activeUI().Root()
+}
+
 // Root returns the root widget. The root widget and all of its children are
 // be drawn on the screen and receive user input. The root widget may be nil.
-func Root() Widget {
-	return root
+func (u *UI) Root() Widget {
+	return u.root
 }
 
-// SetRoot sets the root widget. See [Root] for a description of the root widget.
-// The root widget is focused automatically. Set a nil root widget to disable etk.
+// SetRoot sets the root widget of the default UI. See UI.SetRoot.
 func SetRoot(w Widget) {
-	root = w
-	if root != nil && (lastWidth != 0 || lastHeight != 0) {
-		root.SetRect(image.Rect(0, 0, lastWidth, lastHeight))
+	activeUI().SetRoot(w)
+}
+
+// SetRoot sets the root widget. See [UI.Root] for a description of the root widget.
+// The root widget is focused automatically. Set a nil root widget to disable the UI.
+func (u *UI) SetRoot(w Widget) {
+	u.root = w
+	u.invalidate()
+	if u.root != nil && (u.lastWidth != 0 || u.lastHeight != 0) {
+		u.root.SetRect(image.Rect(0, 0, u.lastWidth, u.lastHeight))
 	}
-	SetFocus(root)
+	u.SetFocus(u.root)
 }
 
-// SetFocus focuses a widget.
+// SetFocus focuses a widget within the default UI. See UI.SetFocus.
 func SetFocus(w Widget) {
-	lastFocused := focusedWidget
-	if w != nil && !w.SetFocus(true) {
+	activeUI().SetFocus(w)
+}
+
+// SetFocus focuses a widget. While a modal widget is shown, only the modal
+// widget and its children may be focused.
+func (u *UI) SetFocus(w Widget) {
+	lastFocused := u.focusedWidget
+	if w != nil && len(u.modals) != 0 && !shown(u.activeRoot(), w) {
+		return
+	} else if w != nil && !w.SetFocus(true) {
 		return
 	}
 	if lastFocused != nil && lastFocused != w {
 		lastFocused.SetFocus(false)
+		MarkDirty(lastFocused)
+	}
+	if u.selectionOwner != nil && u.selectionOwner != w {
+		u.setSelectionOwner(nil)
 	}
-	focusedWidget = w
+	if w != lastFocused {
+		MarkDirty(w)
+		u.invalidate()
+	}
+	u.focusedWidget = w
 }
 
-// Focused returns the currently focused widget. If no widget is focused, nil is returned.
+// Focused returns the focused widget of the default UI. See UI.Focused.
 func Focused() Widget {
-	return focusedWidget
+	return activeUI().Focused()
+}
+
+// Focused returns the currently focused widget. If no widget is focused, nil is returned.
+func (u *UI) Focused() Widget {
+	return u.focusedWidget
 }
 
 func int26ToRect(r fixed.Rectangle26_6) image.Rectangle {
@@ -186,43 +175,77 @@ func BoundString(f *text.GoTextFace, s string) image.Rectangle {
 	return image.Rect(0, 0, int(w), int(h))
 }
 
+// SetDebug sets whether debug information is drawn on screen by the default
+// UI. See UI.SetDebug.
+func SetDebug(debug bool) {
+	activeUI().SetDebug(debug)
+}
+
 // SetDebug sets whether debug information is drawn on screen. When enabled,
 // all visible widgets are outlined.
-func SetDebug(debug bool) {
-	drawDebug = debug
+func (u *UI) SetDebug(debug bool) {
+	u.drawDebug = debug
+	u.invalidate()
 }
 
-// ScreenSize returns the current screen size.
+// ScreenSize returns the screen size of the default UI. See UI.ScreenSize.
 func ScreenSize() (width int, height int) {
-	return lastWidth, lastHeight
+	return activeUI().ScreenSize()
+}
+
+// ScreenSize returns the current screen size.
+func (u *UI) ScreenSize() (width int, height int) {
+	return u.lastWidth, u.lastHeight
+}
+
+// Layout lays out the default UI. See UI.Layout.
+func Layout(outsideWidth int, outsideHeight int) (scaledWidth int, scaledHeight int) {
+	return activeUI().Layout(outsideWidth, outsideHeight)
 }
 
 // Layout sets the screen size and applies device scaling, resizes the root
 // widget and returns the scaled screen size.
-func Layout(outsideWidth int, outsideHeight int) (scaledWidth int, scaledHeight int) {
-	if !lastResize.IsZero() && time.Since(lastResize) < ResizeDebounce && outsideWidth != 0 && outsideHeight != 0 {
-		return lastWidth, lastHeight
+func (u *UI) Layout(outsideWidth int, outsideHeight int) (scaledWidth int, scaledHeight int) {
+	defer u.enter()()
+
+	if !u.lastResize.IsZero() && time.Since(u.lastResize) < ResizeDebounce && outsideWidth != 0 && outsideHeight != 0 {
+		return u.lastWidth, u.lastHeight
 	}
 
 	outsideWidth, outsideHeight = Scale(outsideWidth), Scale(outsideHeight)
-	if outsideWidth != lastWidth || outsideHeight != lastHeight {
-		lastWidth, lastHeight = outsideWidth, outsideHeight
+	if outsideWidth != u.lastWidth || outsideHeight != u.lastHeight {
+		u.lastWidth, u.lastHeight = outsideWidth, outsideHeight
+		u.invalidate()
 	}
 
-	if root == nil {
+	for _, m := range u.modals {
+		m.w.SetRect(image.Rect(0, 0, outsideWidth, outsideHeight))
+	}
+	if u.root == nil {
 		return outsideWidth, outsideHeight
 	}
-	root.SetRect(image.Rect(0, 0, outsideWidth, outsideHeight))
+	u.root.SetRect(image.Rect(0, 0, outsideWidth, outsideHeight))
 	return outsideWidth, outsideHeight
 }
 
-// Update handles user input and passes it to the focused or clicked widget.
+// Update updates the default UI. See UI.Update.
 func Update() error {
-	if root == nil {
+	return activeUI().Update()
+}
+
+// Update handles user input and passes it to the focused or clicked widget.
+func (u *UI) Update() error {
+	defer u.enter()()
+
+	u.consumed = ConsumedInput{}
+	u.updateAnimations()
+
+	top := u.activeRoot()
+	if top == nil {
 		return nil
 	}
 
-	cursor := image.Point{lastX, lastY}
+	cursor := image.Point{u.lastX, u.lastY}
 
 	// Handle touch input.
 
@@ -230,12 +253,12 @@ func Update() error {
 	var clicked bool
 	var touchInput bool
 
-	if activeTouchID == -1 {
-		touchIDs = inpututil.AppendJustPressedTouchIDs(touchIDs[:0])
-		for _, id := range touchIDs {
-			x, y := ebiten.TouchPosition(id)
+	if u.activeTouchID == -1 {
+		u.touchIDs = u.input.AppendJustPressedTouchIDs(u.touchIDs[:0])
+		for _, id := range u.touchIDs {
+			x, y := u.input.TouchPosition(id)
 			if x > 0 || y > 0 {
-				activeTouchID = id
+				u.activeTouchID = id
 
 				clicked = true
 				touchInput = true
@@ -244,108 +267,215 @@ func Update() error {
 		}
 	}
 
-	if activeTouchID != -1 {
-		x, y := ebiten.TouchPosition(activeTouchID)
+	if u.activeTouchID != -1 {
+		x, y := u.input.TouchPosition(u.activeTouchID)
 		if x > 0 || y > 0 {
 			cursor.X, cursor.Y = x, y
-			lastX, lastY = x, y
+			u.lastX, u.lastY = x, y
 			pressed = true
 			touchInput = true
+			u.lastTouch = true
 		} else {
-			activeTouchID = -1
+			u.activeTouchID = -1
 		}
 	}
 
 	// Handle mouse input.
 
-	if !touchInput {
-		x, y := ebiten.CursorPosition()
+	var e MouseEvent
+	if touchInput {
+		e = u.newTouchEvent(cursor, pressed, clicked)
+	} else {
+		x, y := u.input.CursorPosition()
 
-		if (x > 0 || y > 0) && (x != lastCursorX || y != lastCursorY) {
+		if (x > 0 || y > 0) && (x != u.lastCursorX || y != u.lastCursorY) {
 			cursor.X, cursor.Y = x, y
-			lastX, lastY = x, y
-			lastCursorX, lastCursorY = x, y
+			u.lastX, u.lastY = x, y
+			u.lastCursorX, u.lastCursorY = x, y
+			u.lastTouch = false
 		}
 
-		for _, binding := range Bindings.ConfirmMouse {
-			pressed = ebiten.IsMouseButtonPressed(binding)
-			if pressed {
-				break
-			}
-		}
+		e = u.newMouseEvent(cursor)
+		pressed, clicked = e.Pressed && e.confirm(), e.Clicked && e.confirm()
+	}
 
-		for _, binding := range Bindings.ConfirmMouse {
-			clicked = inpututil.IsMouseButtonJustPressed(binding)
-			if clicked {
-				break
-			}
-		}
+	dragging, err := u.handleDrag(cursor, pressed, clicked)
+	if err != nil {
+		return fmt.Errorf("failed to handle drag and drop: %s", err)
+	} else if dragging {
+		u.invalidate()
 	}
 
-	if pressedWidget != nil {
-		c := cursor
-		if c.X <= 0 && c.Y <= 0 {
-			c.X, c.Y = lastX, lastY
+	scrolled, err := u.handleScroll(cursor)
+	if err != nil {
+		return fmt.Errorf("failed to handle widget scroll input: %s", err)
+	}
+
+	mouseConsumed := dragging || scrolled || u.pressedWidget != nil || len(u.modals) != 0
+	if u.pressedWidget != nil && !dragging {
+		pe := mouseEventFor(u.pressedWidget, e)
+		if pe.Cursor.X <= 0 && pe.Cursor.Y <= 0 {
+			pe.Cursor.X, pe.Cursor.Y = u.lastX, u.lastY
 		}
-		_, err := pressedWidget.HandleMouse(c, pressed, clicked)
+		_, err := handleMouseEvent(u.pressedWidget, pe)
 		if err != nil {
 			return err
 		}
-		if !pressed && !clicked {
-			pressedWidget = nil
+		MarkDirty(u.pressedWidget)
+		if !pe.Pressed && !pe.Clicked {
+			u.pressedWidget = nil
+		}
+	}
+
+	u.mouseWidget = nil
+	if !dragging {
+		mouseHandled, err := u.update(top, e, false)
+		if err != nil {
+			return fmt.Errorf("failed to handle widget mouse input: %s", err)
+		} else if !mouseHandled && u.cursorShape != ebiten.CursorShapeDefault {
+			ebiten.SetCursorShape(ebiten.CursorShapeDefault)
+			u.cursorShape = ebiten.CursorShapeDefault
 		}
+		mouseConsumed = mouseConsumed || mouseHandled
+	}
+	if touchInput {
+		u.consumed.Touch = mouseConsumed
+	} else {
+		u.consumed.Mouse = mouseConsumed
 	}
+	if touchInput || u.lastTouch {
+		u.setHovered(nil)
+	} else {
+		u.setHovered(u.mouseWidget)
+	}
+
+	u.updateTooltip(cursor, pressed, clicked, touchInput && !dragging, !touchInput && !u.lastTouch && !dragging)
 
-	mouseHandled, err := update(root, cursor, pressed, clicked, false)
+	// Handle gamepad input.
+
+	u.consumed.Gamepad, err = u.handleGamepads()
 	if err != nil {
-		return fmt.Errorf("failed to handle widget mouse input: %s", err)
-	} else if !mouseHandled && cursorShape != ebiten.CursorShapeDefault {
-		ebiten.SetCursorShape(ebiten.CursorShapeDefault)
-		cursorShape = ebiten.CursorShapeDefault
+		return fmt.Errorf("failed to handle widget gamepad input: %s", err)
 	}
 
 	// Handle keyboard input.
 
-	if focusedWidget == nil {
-		return nil
-	} else if ebiten.IsKeyPressed(ebiten.KeyBackspace) {
-		if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
-			lastBackspaceRepeat = time.Now().Add(backspaceRepeatWait)
-		} else if time.Since(lastBackspaceRepeat) >= backspaceRepeatTime {
-			lastBackspaceRepeat = time.Now()
+	composing, err := u.handleIME()
+	if err != nil {
+		return fmt.Errorf("failed to handle input method: %s", err)
+	}
 
-			_, err := focusedWidget.HandleKeyboard(ebiten.KeyBackspace, 0)
-			if err != nil {
-				return err
-			}
+	u.keyBuffer = u.input.AppendJustPressedKeys(u.keyBuffer[:0])
+	if composing {
+		u.keyBuffer = u.keyBuffer[:0]
+	}
+	var keys int
+	for _, key := range u.keyBuffer {
+		if u.handleFocusKey(key) {
+			u.consumed.Keyboard = true
+			continue
+		}
+		handled, err := u.handleShortcuts(u.newKeyEvent(key, 0), ShortcutBeforeFocused)
+		if err != nil {
+			return fmt.Errorf("failed to handle shortcut: %s", err)
+		} else if handled {
+			u.consumed.Keyboard = true
+			continue
 		}
+		u.keyBuffer[keys] = key
+		keys++
 	}
+	u.keyBuffer = u.keyBuffer[:keys]
+
+	if u.focusedWidget == nil {
+		for _, key := range u.keyBuffer {
+			handled, err := u.handleClipboard(key)
+			if err != nil {
+				return fmt.Errorf("failed to handle clipboard: %s", err)
+			} else if handled {
+				u.consumed.Keyboard = true
+				continue
+			}
 
-	// Handle paste.
-	if inpututil.IsKeyJustPressed(ebiten.KeyV) && ebiten.IsKeyPressed(ebiten.KeyControl) {
-		focused := Focused()
-		if focused != nil {
-			writer, ok := focused.(io.Writer)
+			// Movement keys start navigation from the nearest widget in the
+			// corresponding direction, as movement gamepad buttons do.
+			e := u.newKeyEvent(key, 0)
+			d, ok := directionKey(key)
 			if ok {
-				_, err := writer.Write(clipboardBuffer())
-				return err
+				handled, err = u.handleDirection(d, e)
+				if err != nil {
+					return fmt.Errorf("failed to handle widget keyboard input: %s", err)
+				} else if handled {
+					u.consumed.Keyboard = true
+					continue
+				}
+			}
+			handled, err = u.handleShortcuts(e, ShortcutAfterFocused)
+			if err != nil {
+				return fmt.Errorf("failed to handle shortcut: %s", err)
+			} else if handled {
+				u.consumed.Keyboard = true
 			}
 		}
+		return nil
+	}
+
+	err = u.handleKeyRepeat(u.keyBuffer)
+	if err != nil {
+		return fmt.Errorf("failed to handle widget keyboard input: %s", err)
+	}
+
+	for _, key := range u.keyBuffer {
+		// Handle clipboard.
+		handled, err := u.handleClipboard(key)
+		if err != nil {
+			return fmt.Errorf("failed to handle clipboard: %s", err)
+		} else if handled {
+			u.consumed.Keyboard = true
+			continue
+		}
+
+		e := u.newKeyEvent(key, 0)
+		d, ok := directionKey(key)
+		if ok {
+			handled, err = u.handleDirection(d, e)
+		} else {
+			handled, err = u.dispatchKeyEvent(e)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to handle widget keyboard input: %s", err)
+		} else if handled {
+			u.consumed.Keyboard = true
+			continue
+		}
+		handled, err = u.handleShortcuts(e, ShortcutAfterFocused)
+		if err != nil {
+			return fmt.Errorf("failed to handle shortcut: %s", err)
+		} else if handled {
+			u.consumed.Keyboard = true
+		}
 	}
 
-	keyBuffer = inpututil.AppendJustPressedKeys(keyBuffer[:0])
-	for _, key := range keyBuffer {
-		_, err := focusedWidget.HandleKeyboard(key, 0)
+	u.keyBuffer = u.input.AppendJustReleasedKeys(u.keyBuffer[:0])
+	for _, key := range u.keyBuffer {
+		e := u.newKeyEvent(key, 0)
+		e.Pressed = false
+		_, err := u.dispatchKeyEvent(e)
 		if err != nil {
 			return fmt.Errorf("failed to handle widget keyboard input: %s", err)
 		}
 	}
 
-	runeBuffer = ebiten.AppendInputChars(runeBuffer[:0])
+	// Text entered while a Composer is focused is received via handleIME.
+	if u.ime.composer != nil {
+		return nil
+	}
+
+	u.runeBuffer = u.input.AppendInputChars(u.runeBuffer[:0])
 INPUTCHARS:
-	for i, r := range runeBuffer {
+	for i, r := range u.runeBuffer {
 		if i > 0 {
-			for j, r2 := range runeBuffer {
+			for j, r2 := range u.runeBuffer {
 				if j == i {
 					break
 				} else if r2 == r {
@@ -353,17 +483,11 @@ INPUTCHARS:
 				}
 			}
 		}
-		var err error
-		switch r {
-		case Bindings.ConfirmRune:
-			_, err = focusedWidget.HandleKeyboard(ebiten.KeyEnter, 0)
-		case Bindings.BackRune:
-			_, err = focusedWidget.HandleKeyboard(ebiten.KeyBackspace, 0)
-		default:
-			_, err = focusedWidget.HandleKeyboard(-1, r)
-		}
+		handled, err := u.dispatchKeyEvent(u.newRuneEvent(r))
 		if err != nil {
 			return fmt.Errorf("failed to handle widget keyboard input: %s", err)
+		} else if handled {
+			u.consumed.Keyboard = true
 		}
 	}
 	return nil
@@ -388,12 +512,19 @@ func at(w Widget, p image.Point) Widget {
 	return nil
 }
 
-// At returns the widget at the provided screen location.
+// At returns the widget at the provided screen location within the default
+// UI. See UI.At.
 func At(p image.Point) Widget {
-	return at(root, p)
+	return activeUI().At(p)
+}
+
+// At returns the widget at the provided screen location. While a modal widget
+// is shown, only the topmost modal widget and its children are checked.
+func (u *UI) At(p image.Point) Widget {
+	return at(u.activeRoot(), p)
 }
 
-func update(w Widget, cursor image.Point, pressed bool, clicked bool, mouseHandled bool) (bool, error) {
+func (u *UI) update(w Widget, e MouseEvent, mouseHandled bool) (bool, error) {
 	if w == nil {
 		return false, nil
 	} else if !w.Visible() {
@@ -403,59 +534,102 @@ func update(w Widget, cursor image.Point, pressed bool, clicked bool, mouseHandl
 	var err error
 	children := w.Children()
 	for i := len(children) - 1; i >= 0; i-- {
-		mouseHandled, err = update(children[i], cursor, pressed, clicked, mouseHandled)
+		mouseHandled, err = u.update(children[i], e, mouseHandled)
 		if err != nil {
 			return false, err
 		} else if mouseHandled {
 			return true, nil
 		}
 	}
-	if !mouseHandled && cursor.In(w.Rect()) {
-		if pressed && !clicked && w != pressedWidget {
+	if !mouseHandled && e.Cursor.In(w.Rect()) {
+		e = mouseEventFor(w, e)
+		pressed, clicked := e.Pressed, e.Clicked
+		if pressed && !clicked && w != u.pressedWidget {
 			return mouseHandled, nil
 		}
-		originalFocus := focusedWidget
-		mouseHandled, err = w.HandleMouse(cursor, pressed, clicked)
+		originalFocus := u.focusedWidget
+		mouseHandled, err = handleMouseEvent(w, e)
 		if err != nil {
 			return false, fmt.Errorf("failed to handle widget mouse input: %s", err)
 		} else if mouseHandled {
+			u.mouseWidget = w
+			if pressed || clicked {
+				MarkDirty(w)
+				u.updateSelectionOwner(w, clicked)
+			}
 			if clicked {
-				if focusedWidget == originalFocus {
-					SetFocus(w)
+				if u.focusedWidget == originalFocus && focusOnClick(w) {
+					u.SetFocus(w)
 				}
-				pressedWidget = w
-			} else if pressedWidget != nil && (!pressed || pressedWidget != w) {
-				pressedWidget = nil
+				u.pressedWidget = w
+			} else if u.pressedWidget != nil && (!pressed || u.pressedWidget != w) {
+				u.pressedWidget = nil
 			}
 			shape := w.Cursor()
 			if shape == -1 {
 				shape = ebiten.CursorShapeDefault
 			}
-			if shape != cursorShape {
+			if shape != u.cursorShape {
 				ebiten.SetCursorShape(shape)
-				cursorShape = shape
+				u.cursorShape = shape
 			}
 		}
 	}
 	return mouseHandled, nil
 }
 
-// Draw draws the root widget and its children to the screen.
+// Draw draws the default UI. See UI.Draw.
 func Draw(screen *ebiten.Image) error {
-	foundFocused = false
-	err := draw(root, screen)
+	return activeUI().Draw(screen)
+}
+
+// Draw draws the root widget and its children to the screen.
+func (u *UI) Draw(screen *ebiten.Image) error {
+	defer u.enter()()
+
+	u.dirty = false
+	defer u.clearDirtyWidgets()
+	u.foundFocused = false
+	err := u.draw(u.root, screen)
+	if err != nil {
+		return err
+	}
+	err = u.drawModals(screen)
+	if err != nil {
+		return err
+	}
+	u.drawDrag(screen)
+	err = u.drawTooltip(screen)
 	if err != nil {
 		return err
-	} else if focusedWidget != nil && !foundFocused {
-		SetFocus(nil)
+	} else if u.focusedWidget != nil && !u.foundFocused {
+		u.SetFocus(nil)
 	}
 	return nil
 }
 
-func draw(w Widget, screen *ebiten.Image) error {
-	if w == nil || !w.Visible() {
+func (u *UI) draw(w Widget, screen *ebiten.Image) error {
+	if !u.drawn(w) {
 		return nil
 	}
+	fx := u.effects[w]
+	if fx != nil {
+		return u.drawEffect(w, fx, screen)
+	}
+	return u.drawLayer(w, screen)
+}
+
+// drawLayer draws a widget and its children.
+func (u *UI) drawLayer(w Widget, screen *ebiten.Image) error {
+	c, ok := w.(*WithCache)
+	if ok {
+		return u.drawCache(c, screen)
+	}
+	return u.drawWidget(w, screen)
+}
+
+func (u *UI) drawWidget(w Widget, screen *ebiten.Image) error {
+	u.takeDirty(w)
 
 	r := w.Rect()
 	subScreen := screen
@@ -477,7 +651,7 @@ func draw(w Widget, screen *ebiten.Image) error {
 		return fmt.Errorf("failed to draw widget: %s", err)
 	}
 
-	if drawDebug && !r.Empty() {
+	if u.drawDebug && !r.Empty() {
 		x, y := r.Min.X, r.Min.Y
 		w, h := r.Dx(), r.Dy()
 		screen.SubImage(image.Rect(x, y, x+w, y+1)).(*ebiten.Image).Fill(debugColor)
@@ -488,14 +662,14 @@ func draw(w Widget, screen *ebiten.Image) error {
 
 	children := w.Children()
 	for _, child := range children {
-		err = draw(child, subScreen)
+		err = u.draw(child, subScreen)
 		if err != nil {
 			return fmt.Errorf("failed to draw widget: %s", err)
 		}
 	}
 
-	if w == focusedWidget {
-		foundFocused = true
+	if w == u.focusedWidget {
+		u.foundFocused = true
 	}
 	return nil
 }
@@ -507,6 +681,7 @@ func newText() *messeji.TextField {
 	f.SetScrollBarColors(Style.ScrollAreaColor, Style.ScrollHandleColor)
 	f.SetScrollBorderSize(Scale(Style.ScrollBorderSize))
 	f.SetScrollBorderColors(Style.ScrollBorderTop, Style.ScrollBorderRight, Style.ScrollBorderBottom, Style.ScrollBorderLeft)
+	f.SetSelectionColor(Style.TextSelectionColor)
 	return f
 }
 
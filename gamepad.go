@@ -0,0 +1,69 @@
+package etk
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// handleGamepads handles gamepad input. Pressing a movement button is handled
+// the same way as pressing the corresponding movement key. Pressing a confirm
+// button is handled the same way as pressing the enter key. Returns whether
+// any gamepad input was handled.
+func (u *UI) handleGamepads() (consumed bool, err error) {
+	u.gamepadIDs = u.input.AppendGamepadIDs(u.gamepadIDs[:0])
+	for _, id := range u.gamepadIDs {
+		if !u.input.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+
+		directions := []struct {
+			d       Direction
+			buttons []ebiten.StandardGamepadButton
+			keys    []ebiten.Key
+			key     ebiten.Key
+		}{
+			{DirectionLeft, Bindings.MoveLeftGamepad, Bindings.MoveLeftKeyboard, ebiten.KeyLeft},
+			{DirectionRight, Bindings.MoveRightGamepad, Bindings.MoveRightKeyboard, ebiten.KeyRight},
+			{DirectionUp, Bindings.MoveUpGamepad, Bindings.MoveUpKeyboard, ebiten.KeyUp},
+			{DirectionDown, Bindings.MoveDownGamepad, Bindings.MoveDownKeyboard, ebiten.KeyDown},
+		}
+		for _, direction := range directions {
+			for _, button := range direction.buttons {
+				if !u.input.IsStandardGamepadButtonJustPressed(id, button) {
+					continue
+				}
+				key := direction.key
+				if len(direction.keys) > 0 {
+					key = direction.keys[0]
+				}
+				handled, err := u.handleDirection(direction.d, u.newKeyEvent(key, 0))
+				if err != nil {
+					return consumed, err
+				} else if handled {
+					consumed = true
+				}
+				break
+			}
+		}
+
+		if u.focusedWidget == nil {
+			continue
+		}
+		for _, button := range Bindings.ConfirmGamepad {
+			if !u.input.IsStandardGamepadButtonJustPressed(id, button) {
+				continue
+			}
+			key := ebiten.KeyEnter
+			if len(Bindings.ConfirmKeyboard) > 0 {
+				key = Bindings.ConfirmKeyboard[0]
+			}
+			handled, err := u.dispatchKeyEvent(u.newKeyEvent(key, 0))
+			if err != nil {
+				return consumed, err
+			} else if handled {
+				consumed = true
+			}
+			break
+		}
+	}
+	return consumed, nil
+}
@@ -6,6 +6,7 @@ toolchain go1.24.9
 
 require (
 	github.com/hajimehoshi/ebiten/v2 v2.9.3
+	github.com/rivo/uniseg v0.4.7
 	golang.design/x/clipboard v0.7.1
 	golang.org/x/image v0.32.0
 )
@@ -16,7 +17,6 @@ require (
 	github.com/ebitengine/purego v0.9.0 // indirect
 	github.com/go-text/typesetting v0.3.0 // indirect
 	github.com/jezek/xgb v1.1.1 // indirect
-	github.com/rivo/uniseg v0.4.7 // indirect
 	golang.org/x/exp/shiny v0.0.0-20251017212417-90e834f514db // indirect
 	golang.org/x/mobile v0.0.0-20251021151156-188f512ec823 // indirect
 	golang.org/x/sync v0.17.0 // indirect
@@ -36,7 +36,8 @@ func (g *Grid) SetRect(r image.Rectangle) {
 	defer g.Unlock()
 
 	g.Box.rect = r
-	g.updated = true
+	g.reposition()
+	g.updated = false
 }
 
 // SetColumnSizes sets the size of each column. A size of -1 represents an equal
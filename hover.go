@@ -0,0 +1,59 @@
+package etk
+
+// Hoverer may be implemented by widgets to be notified when the mouse cursor
+// enters and leaves the widget. Only the topmost widget under the mouse cursor
+// which handles mouse events is hovered. Touch input does not hover widgets.
+type Hoverer interface {
+	// MouseEnter is called when the mouse cursor enters the widget.
+	MouseEnter()
+
+	// MouseLeave is called when the mouse cursor leaves the widget.
+	MouseLeave()
+}
+
+// Hovered returns the widget under the mouse cursor within the default UI.
+// See UI.Hovered.
+func Hovered() Widget {
+	return activeUI().Hovered()
+}
+
+// Hovered returns the topmost widget under the mouse cursor which handles
+// mouse events. If no widget is hovered, nil is returned.
+func (u *UI) Hovered() Widget {
+	return u.hoveredWidget
+}
+
+// setHovered changes the hovered widget, notifying the previously hovered
+// widget and the newly hovered widget.
+func (u *UI) setHovered(w Widget) {
+	if w == u.hoveredWidget {
+		return
+	}
+	if u.hoveredWidget != nil {
+		hoverer := findHoverer(u.hoveredWidget)
+		if hoverer != nil {
+			hoverer.MouseLeave()
+		}
+		MarkDirty(u.hoveredWidget)
+	}
+	u.hoveredWidget = w
+	if w != nil {
+		hoverer := findHoverer(w)
+		if hoverer != nil {
+			hoverer.MouseEnter()
+		}
+		MarkDirty(w)
+	}
+}
+
+// findHoverer returns the provided widget, or the widget wrapped by it, as a
+// Hoverer.
+func findHoverer(w Widget) Hoverer {
+	for ; w != nil; w = unwrap(w) {
+		hoverer, ok := w.(Hoverer)
+		if ok {
+			return hoverer
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package etk
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+)
+
+// Composer may be implemented by widgets which accept text entered via an input
+// method editor (IME), such as when entering Chinese, Japanese or Korean text.
+// While a Composer is focused, text being composed is passed to the widget via
+// SetComposition, and committed text is passed to the widget as runes.
+type Composer interface {
+	// CaretBounds returns the location of the insertion point on the screen.
+	// The input method editor shows its candidate window near this location.
+	CaretBounds() image.Rectangle
+
+	// SetComposition sets the text being composed, which is shown at the
+	// insertion point until it is committed. An empty string is provided when
+	// the composition is committed or cancelled.
+	SetComposition(text string)
+}
+
+// findComposer returns the provided widget, or the widget wrapped by it, as a
+// Composer.
+func findComposer(w Widget) Composer {
+	for w != nil {
+		composer, ok := w.(Composer)
+		if ok {
+			return composer
+		}
+		w = unwrap(w)
+	}
+	return nil
+}
+
+// imeField is a text input field which receives text entered via an input
+// method editor. It is implemented by textinput.Field.
+type imeField interface {
+	Focus()
+	Blur()
+	SetTextAndSelection(text string, selectionStartInBytes int, selectionEndInBytes int)
+	HandleInputWithBounds(bounds image.Rectangle) (handled bool, err error)
+	Text() string
+	TextForRendering() string
+	UncommittedTextLengthInBytes() int
+	Selection() (startInBytes int, endInBytes int)
+}
+
+// imeSource is implemented by input sources which receive text entered via an
+// input method editor.
+type imeSource interface {
+	// inputMethod returns the input method shared by all UIs which read
+	// user input from the source.
+	inputMethod() *inputMethod
+}
+
+// inputMethod reads text entered via an input method editor. Its field is
+// shared by all UIs reading user input from the same source, and it is
+// updated once per frame, so that each UI with a focused Composer receives the
+// text entered during the frame exactly once.
+type inputMethod struct {
+	field imeField
+	tick  func() int64
+
+	users       int
+	updated     bool
+	lastTick    int64
+	read        int
+	committed   []rune
+	composition string
+	err         error
+}
+
+// ebitenInputMethod is the input method of all UIs reading user input from
+// Ebitengine.
+var ebitenInputMethod = &inputMethod{
+	field: &textinput.Field{},
+	tick:  ebiten.Tick,
+}
+
+// maxIMEText is the length of committed text in bytes after which the text
+// input field is cleared.
+const maxIMEText = 4096
+
+// acquire focuses the field when it is not already used by another UI.
+func (m *inputMethod) acquire() {
+	if m.users == 0 {
+		m.field.SetTextAndSelection("", 0, 0)
+		m.read, m.committed, m.composition = 0, m.committed[:0], ""
+		m.field.Focus()
+	}
+	m.users++
+}
+
+// release blurs the field when it is no longer used by any UI.
+func (m *inputMethod) release() {
+	m.users--
+	if m.users == 0 {
+		m.field.Blur()
+	}
+}
+
+// update reads the text committed and composed during the current frame. The
+// field is only read once per frame, using the bounds provided by the first
+// UI to call update.
+func (m *inputMethod) update(bounds image.Rectangle) error {
+	tick := m.tick()
+	if m.updated && tick == m.lastTick {
+		return m.err
+	}
+	m.updated, m.lastTick = true, tick
+
+	m.committed = m.committed[:0]
+	_, m.err = m.field.HandleInputWithBounds(bounds)
+	if m.err != nil {
+		return m.err
+	}
+
+	committed := m.field.Text()
+	if len(committed) < m.read {
+		m.read = len(committed)
+	}
+	m.committed = append(m.committed, []rune(committed[m.read:])...)
+	m.read = len(committed)
+
+	m.composition = ""
+	if n := m.field.UncommittedTextLengthInBytes(); n > 0 {
+		start, _ := m.field.Selection()
+		m.composition = m.field.TextForRendering()[start : start+n]
+	}
+	if m.composition == "" && m.read > maxIMEText {
+		m.field.SetTextAndSelection("", 0, 0)
+		m.read = 0
+	}
+	return nil
+}
+
+type imeState struct {
+	method      *inputMethod
+	composer    Composer
+	composition string
+}
+
+// handleIME passes text entered via the input method editor to the focused
+// widget when it is a Composer. Input method editors are only used when the
+// input source implements imeSource, such as when user input is read from
+// Ebitengine. Key presses should not be passed to the focused widget while
+// composing, as they are handled by the input method.
+func (u *UI) handleIME() (composing bool, err error) {
+	var method *inputMethod
+	var composer Composer
+	source, ok := u.input.(imeSource)
+	if ok && u.focusedWidget != nil {
+		composer = findComposer(u.focusedWidget)
+		if composer != nil {
+			method = source.inputMethod()
+		}
+	}
+
+	s := &u.ime
+	if composer != s.composer {
+		if s.composer != nil && s.composition != "" {
+			s.composer.SetComposition("")
+		}
+		s.composer, s.composition = composer, ""
+		if s.method != nil {
+			s.method.release()
+			s.method = nil
+		}
+	}
+	if method != s.method {
+		if s.method != nil {
+			s.method.release()
+		}
+		if method != nil {
+			method.acquire()
+		}
+		s.method = method
+	}
+	if composer == nil {
+		return false, nil
+	}
+	wasComposing := s.composition != ""
+
+	err = method.update(composer.CaretBounds())
+	if err != nil {
+		return false, err
+	}
+
+	// Pass committed text to the focused widget.
+	for _, r := range method.committed {
+		handled, err := u.dispatchKeyEvent(u.newRuneEvent(r))
+		if err != nil {
+			return false, err
+		} else if handled {
+			u.consumed.Keyboard = true
+		}
+	}
+
+	// Pass composed text to the focused widget.
+	if method.composition != s.composition {
+		s.composition = method.composition
+		composer.SetComposition(s.composition)
+		MarkDirty(u.focusedWidget)
+	}
+
+	composing = wasComposing || s.composition != ""
+	if composing {
+		u.consumed.Keyboard = true
+	}
+	return composing, nil
+}
@@ -13,50 +13,22 @@ import (
 // also accepts user input.
 type Input struct {
 	*Box
-	field           *messeji.InputField
-	onChange        func(text string, r rune) (accept bool)
-	onConfirm       func(text string) (handled bool)
-	cursor          string
-	borderSize      int
-	borderFocused   color.RGBA
-	borderUnfocused color.RGBA
-	focus           bool
+	editField
+	onConfirm func(text string) (handled bool)
+	cursor    string
+	mask      rune
 }
 
 // NewInput returns a new Input widget.
 func NewInput(text string, onChange func(text string, r rune) (accept bool), onConfirm func(text string) (handled bool)) *Input {
-	f := messeji.NewInputField(Style.TextFont, Scale(Style.TextSize), fontMutex)
-	f.SetForegroundColor(Style.TextColorLight)
-	f.SetBackgroundColor(transparent)
-	f.SetScrollBarColors(Style.ScrollAreaColor, Style.ScrollHandleColor)
-	f.SetScrollBorderSize(Scale(Style.ScrollBorderSize))
-	f.SetScrollBorderColors(Style.ScrollBorderTop, Style.ScrollBorderRight, Style.ScrollBorderBottom, Style.ScrollBorderLeft)
-	f.SetPrefix("")
-	f.SetSuffix("")
-	f.SetText(text)
-	f.SetHandleKeyboard(true)
-
+	box := NewBox()
 	i := &Input{
-		Box:             NewBox(),
-		field:           f,
-		onChange:        onChange,
-		onConfirm:       onConfirm,
-		cursor:          "_",
-		borderSize:      Scale(Style.InputBorderSize),
-		borderFocused:   Style.InputBorderFocused,
-		borderUnfocused: Style.InputBorderUnfocused,
+		Box:       box,
+		editField: newEditField(box, text, onChange),
+		onConfirm: onConfirm,
 	}
-	i.SetBackground(Style.InputBgColor)
-	f.SetChangedFunc(func(r rune) (accept bool) {
-		if i.onChange != nil {
-			text := f.Text()
-			if r == 0 && len(text) > 0 {
-				return i.onChange(text[:len(text)-1], 0)
-			}
-			return i.onChange(text+string(r), r)
-		}
-		return true
-	})
+	f := i.field
+	f.SetEditFunc(i.editFunc())
 	f.SetSelectedFunc(func() (accept bool) {
 		if i.onConfirm != nil {
 			return i.onConfirm(f.Text())
@@ -66,50 +38,6 @@ func NewInput(text string, onChange func(text string, r rune) (accept bool), onC
 	return i
 }
 
-// SetRect sets the position and size of the widget.
-func (i *Input) SetRect(r image.Rectangle) {
-	i.Box.rect = r
-
-	i.field.SetRect(r)
-
-	for _, w := range i.children {
-		w.SetRect(r)
-	}
-}
-
-// SetBorderSize sets the size of the border around the field.
-func (i *Input) SetBorderSize(size int) {
-	i.Lock()
-	defer i.Unlock()
-
-	i.borderSize = size
-}
-
-// SetBorderColors sets the border colors of the field when focused and unfocused.
-func (i *Input) SetBorderColors(focused color.RGBA, unfocused color.RGBA) {
-	i.Lock()
-	defer i.Unlock()
-
-	i.borderFocused = focused
-	i.borderUnfocused = unfocused
-}
-
-// Foreground return the color of the text within the field.
-func (i *Input) Foreground() color.RGBA {
-	i.Lock()
-	defer i.Unlock()
-
-	return i.field.ForegroundColor()
-}
-
-// SetForegroundColor sets the color of the text within the field.
-func (i *Input) SetForeground(c color.RGBA) {
-	i.Lock()
-	defer i.Unlock()
-
-	i.field.SetForegroundColor(c)
-}
-
 // SetPrefix sets the text shown before the input text.
 func (i *Input) SetPrefix(prefix string) {
 	i.Lock()
@@ -126,206 +54,315 @@ func (i *Input) SetSuffix(suffix string) {
 	i.field.SetSuffix(suffix)
 }
 
-// SetCursor sets the cursor appended to the text buffer when focused.
+// SetCursor sets the cursor appended to the text buffer when focused. The
+// caret is drawn at the insertion point regardless of the cursor, which is
+// empty by default.
 func (i *Input) SetCursor(cursor string) {
 	i.Lock()
 	defer i.Unlock()
 
 	i.cursor = cursor
-	if i.focus {
+	if i.focused {
 		i.field.SetSuffix(cursor)
 	}
 }
 
-// Focus returns the focus state of the widget.
-func (i *Input) Focus() bool {
-	return i.focus
-}
-
 // SetFocus sets the focus state of the widget.
 func (i *Input) SetFocus(focus bool) bool {
-	i.focus = focus
+	i.focused = focus
 
 	var cursor string
 	if focus {
 		cursor = i.cursor
 	}
 	i.field.SetSuffix(cursor)
+	i.field.SetCaretVisible(focus)
 	return true
 }
 
-// Text returns the content of the text buffer.
-func (i *Input) Text() string {
+// SetAutoResize sets whether the font is automatically scaled down when it is
+// too large to fit the entire text buffer on one line.
+func (i *Input) SetAutoResize(resize bool) {
 	i.Lock()
 	defer i.Unlock()
 
-	return i.field.Text()
+	i.field.SetAutoResize(resize)
 }
 
-// SetText sets the text in the field.
-func (i *Input) SetText(text string) {
+// SetHorizontal sets the horizontal alignment of the text within the field.
+func (i *Input) SetHorizontal(h Alignment) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.field.SetText(text)
+	i.field.SetHorizontal(messeji.Alignment(h))
 }
 
-// SetScrollBarWidth sets the width of the scroll bar.
-func (i *Input) SetScrollBarWidth(width int) {
+// SetVertical sets the vertical alignment of the text within the field.
+func (i *Input) SetVertical(v Alignment) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.field.SetScrollBarWidth(width)
+	i.field.SetVertical(messeji.Alignment(v))
 }
 
-// SetScrollBarColors sets the color of the scroll bar area and handle.
-func (i *Input) SetScrollBarColors(area color.RGBA, handle color.RGBA) {
+// SetMask sets the rune used to mask the text buffer contents. Set to 0 to disable.
+func (i *Input) SetMask(r rune) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.field.SetScrollBarColors(Style.ScrollAreaColor, Style.ScrollHandleColor)
+	i.mask = r
+	i.field.SetMask(r)
 }
 
-// SetScrollBarVisible sets whether the scroll bar is visible on the screen.
-func (i *Input) SetScrollBarVisible(scrollVisible bool) {
+// SetConfirmFunc sets the handler called when the text input is confirmed.
+func (i *Input) SetConfirmFunc(onConfirm func(text string) (handled bool)) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.field.SetScrollBarVisible(scrollVisible)
+	i.onConfirm = onConfirm
 }
 
-// SetAutoHideScrollBar sets whether the scroll bar is automatically hidden
-// when the entire text buffer is visible.
-func (i *Input) SetAutoHideScrollBar(autoHide bool) {
+// Copy copies the selected text to the clipboard, or all text in the field
+// when no text is selected. Masked text is not copied.
+func (i *Input) Copy() error {
+	i.Lock()
+	text, mask := i.field.SelectedText(), i.mask
+	if text == "" {
+		text = i.field.Text()
+	}
+	i.Unlock()
+
+	if mask != 0 || text == "" {
+		return nil
+	}
+	return WriteClipboard([]byte(text))
+}
+
+// Cut copies the selected text to the clipboard and removes it from the
+// field. When no text is selected, all text in the field is cut. Masked text
+// is not cut.
+func (i *Input) Cut() error {
+	i.Lock()
+	text, mask := i.field.Text(), i.mask
+	start, end := i.field.Selection()
+	i.Unlock()
+
+	if mask != 0 {
+		return nil
+	} else if start == end {
+		start, end = 0, len(text)
+	}
+	return i.cut(text, start, end)
+}
+
+// Accessibility returns the role, name, value and state of the widget.
+func (i *Input) Accessibility() Accessibility {
 	i.Lock()
 	defer i.Unlock()
 
-	i.field.SetAutoHideScrollBar(autoHide)
+	return Accessibility{
+		Role:    RoleTextbox,
+		Value:   maskText(i.field.Text(), i.mask),
+		Focused: i.focused,
+	}
 }
 
-// SetFont sets the font and text size of the field. Scaling is not applied.
-func (t *Input) SetFont(fnt *text.GoTextFaceSource, size int) {
-	t.Lock()
-	defer t.Unlock()
+// SetRect sets the position and size of the widget.
+func (i *Input) SetRect(r image.Rectangle) {
+	i.setRect(r)
+}
 
-	t.field.SetFont(fnt, size, fontMutex)
+// SetBorderSize sets the size of the border around the field.
+func (i *Input) SetBorderSize(size int) {
+	i.setBorderSize(size)
 }
 
-// SetAutoResize sets whether the font is automatically scaled down when it is
-// too large to fit the entire text buffer on one line.
-func (t *Input) SetAutoResize(resize bool) {
-	t.Lock()
-	defer t.Unlock()
+// SetBorderColors sets the border colors of the field when focused and unfocused.
+func (i *Input) SetBorderColors(focused color.RGBA, unfocused color.RGBA) {
+	i.setBorderColors(focused, unfocused)
+}
 
-	t.field.SetAutoResize(resize)
+// Foreground return the color of the text within the field.
+func (i *Input) Foreground() color.RGBA {
+	return i.foreground()
 }
 
-// Padding returns the amount of padding around the text within the field.
-func (i *Input) Padding() int {
-	i.Lock()
-	defer i.Unlock()
+// SetForeground sets the color of the text within the field.
+func (i *Input) SetForeground(c color.RGBA) {
+	i.setForeground(c)
+}
 
-	return i.field.Padding()
+// Focus returns the focus state of the widget.
+func (i *Input) Focus() bool {
+	return i.focus()
 }
 
-// SetPadding sets the amount of padding around the text within the field.
-func (i *Input) SetPadding(padding int) {
-	i.Lock()
-	defer i.Unlock()
+// Caret returns the position of the insertion point, in bytes from the start
+// of the text.
+func (i *Input) Caret() int {
+	return i.caret()
+}
 
-	i.field.SetPadding(padding)
+// SetCaret sets the position of the insertion point, in bytes from the start
+// of the text. The position is moved to the nearest valid position within the
+// text, and the field is scrolled to show the caret.
+func (i *Input) SetCaret(pos int) {
+	i.setCaret(pos)
 }
 
-// SetWordWrap sets a flag which, when enabled, causes text to wrap without breaking words.
-func (i *Input) SetWordWrap(wrap bool) {
-	i.Lock()
-	defer i.Unlock()
+// Text returns the content of the text buffer.
+func (i *Input) Text() string {
+	return i.text()
+}
 
-	i.field.SetWordWrap(wrap)
+// SetText sets the text in the field.
+func (i *Input) SetText(text string) {
+	i.setText(text)
 }
 
-// SetHorizontal sets the horizontal alignment of the text within the field.
-func (i *Input) SetHorizontal(h Alignment) {
-	i.Lock()
-	defer i.Unlock()
+// Undo undoes the last edit. It returns whether an edit was undone. Edits may
+// also be undone by pressing Ctrl+Z.
+func (i *Input) Undo() bool {
+	return i.undo()
+}
 
-	i.field.SetHorizontal(messeji.Alignment(h))
+// Redo redoes the last edit which was undone. It returns whether an edit was
+// redone. Edits may also be redone by pressing Ctrl+Y or Ctrl+Shift+Z.
+func (i *Input) Redo() bool {
+	return i.redo()
 }
 
-// SetVertical sets the vertical alignment of the text within the field.
-func (i *Input) SetVertical(v Alignment) {
-	i.Lock()
-	defer i.Unlock()
+// SetHistorySize sets the maximum number of edits which may be undone. A size
+// of 0 disables undo and redo. The default size is 100.
+func (i *Input) SetHistorySize(size int) {
+	i.setHistorySize(size)
+}
 
-	i.field.SetVertical(messeji.Alignment(v))
+// SetClearHistoryOnSetText sets whether the edit history is cleared when the
+// text is set via SetText. When disabled, setting the text is recorded as an
+// edit which may be undone. The history is cleared by default.
+func (i *Input) SetClearHistoryOnSetText(clear bool) {
+	i.setClearHistoryOnSetText(clear)
 }
 
-// SetMask sets the rune used to mask the text buffer contents. Set to 0 to disable.
-func (i *Input) SetMask(r rune) {
-	i.Lock()
-	defer i.Unlock()
+// ClearHistory clears the edit history.
+func (i *Input) ClearHistory() {
+	i.clearHistory()
+}
 
-	i.field.SetMask(r)
+// SetScrollBarWidth sets the width of the scroll bar.
+func (i *Input) SetScrollBarWidth(width int) {
+	i.setScrollBarWidth(width)
 }
 
-// SetChangeFunc sets the handler called when the text input changes. When the
-// backspace key is pressed, the current text and a rune value of 0 is passed.
-func (i *Input) SetChangeFunc(onChange func(text string, r rune) (accept bool)) {
-	i.Lock()
-	defer i.Unlock()
+// SetScrollBarColors sets the color of the scroll bar area and handle.
+func (i *Input) SetScrollBarColors(area color.RGBA, handle color.RGBA) {
+	i.setScrollBarColors(area, handle)
+}
 
-	i.onChange = onChange
+// SetScrollBarVisible sets whether the scroll bar is visible on the screen.
+func (i *Input) SetScrollBarVisible(scrollVisible bool) {
+	i.setScrollBarVisible(scrollVisible)
 }
 
-// SetConfirmFunc sets the handler called when the text input is confirmed.
-func (i *Input) SetConfirmFunc(onConfirm func(text string) (handled bool)) {
-	i.Lock()
-	defer i.Unlock()
+// SetAutoHideScrollBar sets whether the scroll bar is automatically hidden
+// when the entire text buffer is visible.
+func (i *Input) SetAutoHideScrollBar(autoHide bool) {
+	i.setAutoHideScrollBar(autoHide)
+}
 
-	i.onConfirm = onConfirm
+// SetFont sets the font and text size of the field. Scaling is not applied.
+func (i *Input) SetFont(fnt *text.GoTextFaceSource, size int) {
+	i.setFont(fnt, size)
+}
+
+// Padding returns the amount of padding around the text within the field.
+func (i *Input) Padding() int {
+	return i.padding()
+}
+
+// SetPadding sets the amount of padding around the text within the field.
+func (i *Input) SetPadding(padding int) {
+	i.setPadding(padding)
+}
+
+// SetWordWrap sets a flag which, when enabled, causes text to wrap without breaking words.
+func (i *Input) SetWordWrap(wrap bool) {
+	i.setWordWrap(wrap)
+}
+
+// SetChangeFunc sets the handler called when the text changes. The text as it
+// will be after the change is passed along with the rune which was entered.
+// When text is deleted, cut or indented, a rune value of 0 is passed. The
+// handler may return false to reject the change.
+func (i *Input) SetChangeFunc(onChange func(text string, r rune) (accept bool)) {
+	i.setChangeFunc(onChange)
 }
 
 // Cursor returns the cursor shape shown when a mouse cursor hovers over the
 // widget, or -1 to let widgets beneath determine the cursor shape.
 func (i *Input) Cursor() ebiten.CursorShapeType {
-	return ebiten.CursorShapeText
+	return i.cursorShape()
+}
+
+// Selection returns the bounds of the selected text, in bytes from the start
+// of the text. When no text is selected, start and end are both the position
+// of the caret.
+func (i *Input) Selection() (start int, end int) {
+	return i.selection()
+}
+
+// SetSelection selects the text between the provided positions, in bytes from
+// the start of the text, and moves the caret to end.
+func (i *Input) SetSelection(start int, end int) {
+	i.setSelection(start, end)
 }
 
-// Write writes to the text buffer.
+// SelectedText returns the selected text.
+func (i *Input) SelectedText() string {
+	return i.selectedText()
+}
+
+// Write writes to the text buffer at the insertion point, replacing the
+// selected text.
 func (i *Input) Write(p []byte) (n int, err error) {
-	return i.field.Write(p)
+	return i.write(p)
+}
+
+// CaretBounds returns the location of the insertion point on the screen.
+func (i *Input) CaretBounds() image.Rectangle {
+	return i.caretBounds()
+}
+
+// SetComposition sets the text being composed via an input method editor. The
+// composition is shown underlined at the insertion point.
+func (i *Input) SetComposition(text string) {
+	i.setComposition(text)
 }
 
 // HandleKeyboard is called when a keyboard event occurs.
 func (i *Input) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
-	if !i.focus {
-		return false, nil
-	}
+	return i.handleKeyboard(key, r)
+}
 
-	return i.field.HandleKeyboardEvent(key, r)
+// HandleKeyEvent is called when a key is pressed or released, or a rune is
+// entered. Modifier keys are passed to the field so that word movement,
+// selection and deletion may be handled.
+func (i *Input) HandleKeyEvent(event KeyEvent) (handled bool, err error) {
+	return i.handleKeyEvent(event)
 }
 
 // HandleMouse is called when a mouse event occurs.
 func (i *Input) HandleMouse(cursor image.Point, pressed bool, clicked bool) (handled bool, err error) {
-	return i.field.HandleMouseEvent(cursor, pressed, clicked)
+	return i.handleMouse(cursor, pressed, clicked)
+}
+
+// HandleScroll is called when the mouse wheel is scrolled over the widget.
+func (i *Input) HandleScroll(x float64, y float64) (handled bool, err error) {
+	return i.handleScroll(x, y)
 }
 
 // Draw draws the widget on the screen.
 func (i *Input) Draw(screen *ebiten.Image) error {
-	i.field.Draw(screen)
-
-	// Draw border.
-	if i.borderSize == 0 {
-		return nil
-	}
-	r := i.rect
-	c := i.borderUnfocused
-	if i.focus {
-		c = i.borderFocused
-	}
-	screen.SubImage(image.Rect(r.Min.X, r.Min.Y, r.Min.X+i.borderSize, r.Max.Y)).(*ebiten.Image).Fill(c)
-	screen.SubImage(image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+i.borderSize)).(*ebiten.Image).Fill(c)
-	screen.SubImage(image.Rect(r.Max.X-i.borderSize, r.Min.Y, r.Max.X, r.Max.Y)).(*ebiten.Image).Fill(c)
-	screen.SubImage(image.Rect(r.Min.X, r.Max.Y-i.borderSize, r.Max.X, r.Max.Y)).(*ebiten.Image).Fill(c)
-	return nil
+	return i.draw(screen)
 }
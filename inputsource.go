@@ -0,0 +1,480 @@
+package etk
+
+import (
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// InputSource provides the keyboard, mouse, touch and gamepad input handled
+// by etk. By default, input is read from Ebitengine. Set an alternate source
+// via SetInputSource to drive etk with scripted or remote input.
+type InputSource interface {
+	// CursorPosition returns the position of the mouse cursor.
+	CursorPosition() (x int, y int)
+
+	// IsMouseButtonPressed returns whether a mouse button is pressed.
+	IsMouseButtonPressed(button ebiten.MouseButton) bool
+
+	// IsMouseButtonJustPressed returns whether a mouse button was pressed
+	// during the current frame.
+	IsMouseButtonJustPressed(button ebiten.MouseButton) bool
+
+	// Wheel returns the mouse wheel movement during the current frame.
+	Wheel() (x float64, y float64)
+
+	// AppendJustPressedTouchIDs appends the IDs of touches which started
+	// during the current frame to the provided slice.
+	AppendJustPressedTouchIDs(touchIDs []ebiten.TouchID) []ebiten.TouchID
+
+	// TouchPosition returns the position of a touch. When the touch has
+	// ended, the position is 0, 0.
+	TouchPosition(id ebiten.TouchID) (x int, y int)
+
+	// IsKeyPressed returns whether a key is pressed.
+	IsKeyPressed(key ebiten.Key) bool
+
+	// IsKeyJustPressed returns whether a key was pressed during the current
+	// frame.
+	IsKeyJustPressed(key ebiten.Key) bool
+
+	// AppendJustPressedKeys appends the keys which were pressed during the
+	// current frame to the provided slice.
+	AppendJustPressedKeys(keys []ebiten.Key) []ebiten.Key
+
+	// AppendJustReleasedKeys appends the keys which were released during the
+	// current frame to the provided slice.
+	AppendJustReleasedKeys(keys []ebiten.Key) []ebiten.Key
+
+	// AppendInputChars appends the characters which were input during the
+	// current frame to the provided slice.
+	AppendInputChars(runes []rune) []rune
+
+	// AppendGamepadIDs appends the IDs of connected gamepads to the provided
+	// slice.
+	AppendGamepadIDs(gamepadIDs []ebiten.GamepadID) []ebiten.GamepadID
+
+	// IsStandardGamepadLayoutAvailable returns whether a gamepad has a
+	// standard button layout.
+	IsStandardGamepadLayoutAvailable(id ebiten.GamepadID) bool
+
+	// IsStandardGamepadButtonJustPressed returns whether a gamepad button was
+	// pressed during the current frame.
+	IsStandardGamepadButtonJustPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool
+}
+
+// ActiveInputSource returns the source of user input of the default UI. See
+// UI.ActiveInputSource.
+func ActiveInputSource() InputSource {
+	return activeUI().ActiveInputSource()
+}
+
+// ActiveInputSource returns the source of user input.
+func (u *UI) ActiveInputSource() InputSource {
+	return u.input
+}
+
+// SetInputSource sets the source of user input of the default UI. See
+// UI.SetInputSource.
+func SetInputSource(source InputSource) {
+	activeUI().SetInputSource(source)
+}
+
+// SetInputSource sets the source of user input. Providing a nil value
+// restores the default source, which reads input from Ebitengine.
+func (u *UI) SetInputSource(source InputSource) {
+	if source == nil {
+		source = ebitenInput{}
+	}
+	u.input = source
+}
+
+// ebitenInput reads user input from Ebitengine.
+type ebitenInput struct{}
+
+func (ebitenInput) CursorPosition() (x int, y int) {
+	return ebiten.CursorPosition()
+}
+
+func (ebitenInput) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return ebiten.IsMouseButtonPressed(button)
+}
+
+func (ebitenInput) IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	return inpututil.IsMouseButtonJustPressed(button)
+}
+
+func (ebitenInput) Wheel() (x float64, y float64) {
+	return ebiten.Wheel()
+}
+
+func (ebitenInput) AppendJustPressedTouchIDs(touchIDs []ebiten.TouchID) []ebiten.TouchID {
+	return inpututil.AppendJustPressedTouchIDs(touchIDs)
+}
+
+func (ebitenInput) TouchPosition(id ebiten.TouchID) (x int, y int) {
+	return ebiten.TouchPosition(id)
+}
+
+func (ebitenInput) IsKeyPressed(key ebiten.Key) bool {
+	return ebiten.IsKeyPressed(key)
+}
+
+func (ebitenInput) IsKeyJustPressed(key ebiten.Key) bool {
+	return inpututil.IsKeyJustPressed(key)
+}
+
+func (ebitenInput) AppendJustPressedKeys(keys []ebiten.Key) []ebiten.Key {
+	return inpututil.AppendJustPressedKeys(keys)
+}
+
+func (ebitenInput) AppendJustReleasedKeys(keys []ebiten.Key) []ebiten.Key {
+	return inpututil.AppendJustReleasedKeys(keys)
+}
+
+func (ebitenInput) AppendInputChars(runes []rune) []rune {
+	return ebiten.AppendInputChars(runes)
+}
+
+func (ebitenInput) inputMethod() *inputMethod {
+	return ebitenInputMethod
+}
+
+func (ebitenInput) AppendGamepadIDs(gamepadIDs []ebiten.GamepadID) []ebiten.GamepadID {
+	return ebiten.AppendGamepadIDs(gamepadIDs)
+}
+
+func (ebitenInput) IsStandardGamepadLayoutAvailable(id ebiten.GamepadID) bool {
+	return ebiten.IsStandardGamepadLayoutAvailable(id)
+}
+
+func (ebitenInput) IsStandardGamepadButtonJustPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool {
+	return inpututil.IsStandardGamepadButtonJustPressed(id, button)
+}
+
+// FakeInput is an input source which allows tests to simulate user input.
+// Input is applied frame by frame: call the methods of FakeInput to change the
+// input state, call Update to handle the input, and then call Advance to begin
+// the next frame.
+//
+//	f := etk.NewFakeInput()
+//	etk.SetInputSource(f)
+//	f.MoveCursor(10, 10)
+//	f.PressMouse(ebiten.MouseButtonLeft)
+//	etk.Update()
+//	f.Advance()
+//	f.ReleaseMouse(ebiten.MouseButtonLeft)
+//	etk.Update()
+//	f.Advance()
+type FakeInput struct {
+	cursorX, cursorY int
+
+	buttons     map[ebiten.MouseButton]bool
+	lastButtons map[ebiten.MouseButton]bool
+
+	wheelX, wheelY float64
+
+	touches     map[ebiten.TouchID][2]int
+	lastTouches map[ebiten.TouchID][2]int
+
+	keys     map[ebiten.Key]bool
+	lastKeys map[ebiten.Key]bool
+
+	runes []rune
+
+	frame       int64
+	composition string
+	ime         *inputMethod
+
+	gamepads       map[ebiten.GamepadID]bool
+	gamepadPressed map[ebiten.GamepadID]map[ebiten.StandardGamepadButton]bool
+}
+
+// NewFakeInput returns a new FakeInput with no input.
+func NewFakeInput() *FakeInput {
+	f := &FakeInput{
+		buttons:        make(map[ebiten.MouseButton]bool),
+		lastButtons:    make(map[ebiten.MouseButton]bool),
+		touches:        make(map[ebiten.TouchID][2]int),
+		lastTouches:    make(map[ebiten.TouchID][2]int),
+		keys:           make(map[ebiten.Key]bool),
+		lastKeys:       make(map[ebiten.Key]bool),
+		gamepads:       make(map[ebiten.GamepadID]bool),
+		gamepadPressed: make(map[ebiten.GamepadID]map[ebiten.StandardGamepadButton]bool),
+	}
+	f.ime = &inputMethod{
+		field: &fakeIMEField{f: f},
+		tick:  func() int64 { return f.frame },
+	}
+	return f
+}
+
+// Advance begins the next frame. Presses and releases become held states,
+// and wheel movement, typed characters and gamepad button presses are cleared.
+func (f *FakeInput) Advance() {
+	f.frame++
+	clear(f.lastButtons)
+	for button, pressed := range f.buttons {
+		f.lastButtons[button] = pressed
+	}
+	clear(f.lastTouches)
+	for id, position := range f.touches {
+		f.lastTouches[id] = position
+	}
+	clear(f.lastKeys)
+	for key, pressed := range f.keys {
+		f.lastKeys[key] = pressed
+	}
+	f.wheelX, f.wheelY = 0, 0
+	f.runes = f.runes[:0]
+	clear(f.gamepadPressed)
+}
+
+// MoveCursor moves the mouse cursor.
+func (f *FakeInput) MoveCursor(x int, y int) {
+	f.cursorX, f.cursorY = x, y
+}
+
+// PressMouse presses a mouse button.
+func (f *FakeInput) PressMouse(button ebiten.MouseButton) {
+	f.buttons[button] = true
+}
+
+// ReleaseMouse releases a mouse button.
+func (f *FakeInput) ReleaseMouse(button ebiten.MouseButton) {
+	delete(f.buttons, button)
+}
+
+// ScrollWheel scrolls the mouse wheel during the current frame.
+func (f *FakeInput) ScrollWheel(x float64, y float64) {
+	f.wheelX += x
+	f.wheelY += y
+}
+
+// Touch starts or moves a touch.
+func (f *FakeInput) Touch(id ebiten.TouchID, x int, y int) {
+	f.touches[id] = [2]int{x, y}
+}
+
+// ReleaseTouch ends a touch.
+func (f *FakeInput) ReleaseTouch(id ebiten.TouchID) {
+	delete(f.touches, id)
+}
+
+// PressKey presses a key.
+func (f *FakeInput) PressKey(key ebiten.Key) {
+	f.keys[key] = true
+}
+
+// ReleaseKey releases a key.
+func (f *FakeInput) ReleaseKey(key ebiten.Key) {
+	delete(f.keys, key)
+}
+
+// InputChars types characters during the current frame.
+func (f *FakeInput) InputChars(runes ...rune) {
+	f.runes = append(f.runes, runes...)
+}
+
+// Compose sets the text being composed via the input method editor. The text
+// remains until it is changed or committed. Provide an empty string to cancel
+// composing.
+func (f *FakeInput) Compose(text string) {
+	f.composition = text
+}
+
+// Commit ends composing and types the provided text during the current frame.
+// As with Ebitengine, characters typed while a Composer is focused are received
+// via the input method editor.
+func (f *FakeInput) Commit(text string) {
+	f.composition = ""
+	f.runes = append(f.runes, []rune(text)...)
+}
+
+// ConnectGamepad connects a gamepad with a standard button layout.
+func (f *FakeInput) ConnectGamepad(id ebiten.GamepadID) {
+	f.gamepads[id] = true
+}
+
+// DisconnectGamepad disconnects a gamepad.
+func (f *FakeInput) DisconnectGamepad(id ebiten.GamepadID) {
+	delete(f.gamepads, id)
+	delete(f.gamepadPressed, id)
+}
+
+// PressGamepadButton presses a gamepad button during the current frame.
+func (f *FakeInput) PressGamepadButton(id ebiten.GamepadID, button ebiten.StandardGamepadButton) {
+	if f.gamepadPressed[id] == nil {
+		f.gamepadPressed[id] = make(map[ebiten.StandardGamepadButton]bool)
+	}
+	f.gamepadPressed[id][button] = true
+}
+
+// CursorPosition returns the position of the mouse cursor.
+func (f *FakeInput) CursorPosition() (x int, y int) {
+	return f.cursorX, f.cursorY
+}
+
+// IsMouseButtonPressed returns whether a mouse button is pressed.
+func (f *FakeInput) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return f.buttons[button]
+}
+
+// IsMouseButtonJustPressed returns whether a mouse button was pressed during
+// the current frame.
+func (f *FakeInput) IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	return f.buttons[button] && !f.lastButtons[button]
+}
+
+// Wheel returns the mouse wheel movement during the current frame.
+func (f *FakeInput) Wheel() (x float64, y float64) {
+	return f.wheelX, f.wheelY
+}
+
+// AppendJustPressedTouchIDs appends the IDs of touches which started during
+// the current frame to the provided slice.
+func (f *FakeInput) AppendJustPressedTouchIDs(touchIDs []ebiten.TouchID) []ebiten.TouchID {
+	start := len(touchIDs)
+	for id := range f.touches {
+		_, ok := f.lastTouches[id]
+		if !ok {
+			touchIDs = append(touchIDs, id)
+		}
+	}
+	slices.Sort(touchIDs[start:])
+	return touchIDs
+}
+
+// TouchPosition returns the position of a touch. When the touch has ended,
+// the position is 0, 0.
+func (f *FakeInput) TouchPosition(id ebiten.TouchID) (x int, y int) {
+	position := f.touches[id]
+	return position[0], position[1]
+}
+
+// IsKeyPressed returns whether a key is pressed.
+func (f *FakeInput) IsKeyPressed(key ebiten.Key) bool {
+	switch key {
+	case ebiten.KeyControl:
+		return f.keys[key] || f.keys[ebiten.KeyControlLeft] || f.keys[ebiten.KeyControlRight]
+	case ebiten.KeyShift:
+		return f.keys[key] || f.keys[ebiten.KeyShiftLeft] || f.keys[ebiten.KeyShiftRight]
+	case ebiten.KeyAlt:
+		return f.keys[key] || f.keys[ebiten.KeyAltLeft] || f.keys[ebiten.KeyAltRight]
+	case ebiten.KeyMeta:
+		return f.keys[key] || f.keys[ebiten.KeyMetaLeft] || f.keys[ebiten.KeyMetaRight]
+	}
+	return f.keys[key]
+}
+
+// IsKeyJustPressed returns whether a key was pressed during the current frame.
+func (f *FakeInput) IsKeyJustPressed(key ebiten.Key) bool {
+	return f.keys[key] && !f.lastKeys[key]
+}
+
+// AppendJustPressedKeys appends the keys which were pressed during the
+// current frame to the provided slice.
+func (f *FakeInput) AppendJustPressedKeys(keys []ebiten.Key) []ebiten.Key {
+	start := len(keys)
+	for key := range f.keys {
+		if !f.lastKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys[start:])
+	return keys
+}
+
+// AppendJustReleasedKeys appends the keys which were released during the
+// current frame to the provided slice.
+func (f *FakeInput) AppendJustReleasedKeys(keys []ebiten.Key) []ebiten.Key {
+	start := len(keys)
+	for key := range f.lastKeys {
+		if !f.keys[key] {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys[start:])
+	return keys
+}
+
+// AppendInputChars appends the characters which were input during the current
+// frame to the provided slice.
+func (f *FakeInput) AppendInputChars(runes []rune) []rune {
+	return append(runes, f.runes...)
+}
+
+func (f *FakeInput) inputMethod() *inputMethod {
+	return f.ime
+}
+
+// AppendGamepadIDs appends the IDs of connected gamepads to the provided
+// slice.
+func (f *FakeInput) AppendGamepadIDs(gamepadIDs []ebiten.GamepadID) []ebiten.GamepadID {
+	start := len(gamepadIDs)
+	for id := range f.gamepads {
+		gamepadIDs = append(gamepadIDs, id)
+	}
+	slices.Sort(gamepadIDs[start:])
+	return gamepadIDs
+}
+
+// IsStandardGamepadLayoutAvailable returns whether a gamepad has a standard
+// button layout.
+func (f *FakeInput) IsStandardGamepadLayoutAvailable(id ebiten.GamepadID) bool {
+	return f.gamepads[id]
+}
+
+// IsStandardGamepadButtonJustPressed returns whether a gamepad button was
+// pressed during the current frame.
+func (f *FakeInput) IsStandardGamepadButtonJustPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool {
+	return f.gamepads[id] && f.gamepadPressed[id][button]
+}
+
+// fakeIMEField is the input method editor field of a FakeInput.
+type fakeIMEField struct {
+	f       *FakeInput
+	text    string
+	focused bool
+}
+
+func (i *fakeIMEField) Focus() {
+	i.focused = true
+}
+
+func (i *fakeIMEField) Blur() {
+	i.focused = false
+}
+
+func (i *fakeIMEField) SetTextAndSelection(text string, selectionStartInBytes int, selectionEndInBytes int) {
+	i.text = text
+}
+
+func (i *fakeIMEField) HandleInputWithBounds(bounds image.Rectangle) (handled bool, err error) {
+	if !i.focused {
+		return false, nil
+	}
+	i.text += string(i.f.runes)
+	return len(i.f.runes) > 0 || i.f.composition != "", nil
+}
+
+func (i *fakeIMEField) Text() string {
+	return i.text
+}
+
+func (i *fakeIMEField) TextForRendering() string {
+	return i.text + i.f.composition
+}
+
+func (i *fakeIMEField) UncommittedTextLengthInBytes() int {
+	if !i.focused {
+		return 0
+	}
+	return len(i.f.composition)
+}
+
+func (i *fakeIMEField) Selection() (startInBytes int, endInBytes int) {
+	return len(i.text), len(i.text)
+}
@@ -0,0 +1,212 @@
+package etk_test
+
+import (
+	"image"
+	"testing"
+
+	"codeberg.org/tslocum/etk"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type testWidget struct {
+	*etk.Box
+	focus  bool
+	clicks int
+	keys   []ebiten.Key
+	runes  []rune
+}
+
+func newTestWidget() *testWidget {
+	return &testWidget{
+		Box: etk.NewBox(),
+	}
+}
+
+func (w *testWidget) Focus() bool {
+	return w.focus
+}
+
+func (w *testWidget) SetFocus(focus bool) (accept bool) {
+	w.focus = focus
+	return true
+}
+
+func (w *testWidget) HandleMouse(cursor image.Point, pressed bool, clicked bool) (handled bool, err error) {
+	if clicked {
+		w.clicks++
+	}
+	return true, nil
+}
+
+func (w *testWidget) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
+	if key != -1 {
+		w.keys = append(w.keys, key)
+	} else {
+		w.runes = append(w.runes, r)
+	}
+	return true, nil
+}
+
+// setupStyle sets the scale factor used by tests and loads the default font.
+// It must be called before creating widgets which are passed to
+// setupFakeInput.
+func setupStyle(t *testing.T) {
+	t.Setenv("ETK_SCALE", "1")
+	if etk.Style.TextFont == nil {
+		newGame()
+	}
+}
+
+// setupFakeInput returns a UI which reads user input from a FakeInput, laid
+// out at 200x100. When root is nil, the root widget is a grid containing two
+// test widgets side by side, which are returned. Otherwise, root is used and
+// no test widgets are returned.
+func setupFakeInput(t *testing.T, root etk.Widget) (*etk.UI, *etk.FakeInput, *testWidget, *testWidget) {
+	setupStyle(t)
+
+	u := etk.NewUI()
+	f := etk.NewFakeInput()
+	u.SetInputSource(f)
+
+	var a, b *testWidget
+	if root == nil {
+		a, b = newTestWidget(), newTestWidget()
+		g := etk.NewGrid()
+		g.AddChildAt(a, 0, 0, 1, 1)
+		g.AddChildAt(b, 1, 0, 1, 1)
+		root = g
+	}
+	u.SetRoot(root)
+	u.Layout(200, 100)
+	return u, f, a, b
+}
+
+func frame(t *testing.T, u *etk.UI, f *etk.FakeInput) {
+	err := u.Update()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Advance()
+}
+
+func TestFakeInputMouse(t *testing.T) {
+	u, f, a, b := setupFakeInput(t, nil)
+
+	f.MoveCursor(150, 50)
+	f.PressMouse(ebiten.MouseButtonLeft)
+	frame(t, u, f)
+	f.ReleaseMouse(ebiten.MouseButtonLeft)
+	frame(t, u, f)
+
+	if a.clicks != 0 || b.clicks != 1 {
+		t.Fatalf("unexpected clicks: expected 0 and 1, got %d and %d", a.clicks, b.clicks)
+	} else if u.Focused() != b {
+		t.Fatalf("clicked widget was not focused")
+	}
+}
+
+func TestFakeInputKeyboard(t *testing.T) {
+	u, f, a, b := setupFakeInput(t, nil)
+
+	u.SetFocus(a)
+	f.PressKey(ebiten.KeyTab)
+	frame(t, u, f)
+	f.ReleaseKey(ebiten.KeyTab)
+	frame(t, u, f)
+	if u.Focused() != b {
+		t.Fatalf("focus was not moved by tab key")
+	}
+
+	f.PressKey(ebiten.KeyBackspace)
+	f.InputChars('h', 'i')
+	frame(t, u, f)
+	if len(b.keys) != 1 || b.keys[0] != ebiten.KeyBackspace {
+		t.Fatalf("unexpected keys: expected [%d], got %v", ebiten.KeyBackspace, b.keys)
+	} else if string(b.runes) != "hi" {
+		t.Fatalf("unexpected runes: expected hi, got %s", string(b.runes))
+	} else if len(a.keys) != 0 || len(a.runes) != 0 {
+		t.Fatalf("unfocused widget received keyboard input")
+	}
+}
+
+func TestFakeInputIME(t *testing.T) {
+	setupStyle(t)
+
+	input := etk.NewInput("", nil, nil)
+	u, f, _, _ := setupFakeInput(t, input)
+	u.SetFocus(input)
+
+	f.Compose("にほん")
+	frame(t, u, f)
+	if input.Text() != "" {
+		t.Fatalf("composed text was entered: got %s", input.Text())
+	}
+
+	// Committed text is entered once, although Ebitengine also reports it as
+	// typed characters.
+	f.Commit("日本")
+	frame(t, u, f)
+	if input.Text() != "日本" {
+		t.Fatalf("unexpected text: expected 日本, got %s", input.Text())
+	}
+
+	f.InputChars('a', 'b')
+	frame(t, u, f)
+	if input.Text() != "日本ab" {
+		t.Fatalf("unexpected text: expected 日本ab, got %s", input.Text())
+	}
+}
+
+func TestTabIndex(t *testing.T) {
+	u, _, a, b := setupFakeInput(t, nil)
+
+	etk.SetTabIndex(b, -1)
+	if etk.TabIndex(b) != -1 || etk.TabIndex(a) != 0 {
+		t.Fatalf("unexpected tab indexes: %d and %d", etk.TabIndex(a), etk.TabIndex(b))
+	}
+	u.SetFocus(a)
+	u.FocusNext()
+	if u.Focused() != a {
+		t.Fatal("focus moved to widget with a negative tab index")
+	}
+
+	etk.SetTabIndex(b, 0)
+	u.FocusNext()
+	if u.Focused() != b {
+		t.Fatal("focus did not move to widget after its tab index was reset")
+	}
+}
+
+func TestClickFocus(t *testing.T) {
+	setupStyle(t)
+
+	input := etk.NewInput("", nil, nil)
+	var pressed int
+	button := etk.NewButton("Send", func() error {
+		pressed++
+		return nil
+	})
+	g := etk.NewGrid()
+	g.AddChildAt(input, 0, 0, 1, 1)
+	g.AddChildAt(button, 1, 0, 1, 1)
+	u, f, _, _ := setupFakeInput(t, g)
+
+	// Clicking a button leaves the input focused.
+	u.SetFocus(input)
+	f.MoveCursor(150, 50)
+	f.PressMouse(ebiten.MouseButtonLeft)
+	frame(t, u, f)
+	f.ReleaseMouse(ebiten.MouseButtonLeft)
+	frame(t, u, f)
+	if pressed != 1 {
+		t.Fatalf("unexpected button presses: expected 1, got %d", pressed)
+	} else if u.Focused() != input {
+		t.Fatal("clicking a button took focus from the input")
+	}
+
+	// Buttons are still focused via focus traversal.
+	u.FocusNext()
+	if u.Focused() != button {
+		t.Fatal("button was not focused via focus traversal")
+	}
+}
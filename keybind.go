@@ -10,20 +10,59 @@ import (
 type Shortcuts struct {
 	DoubleClickThreshold time.Duration
 
+	// LongPressThreshold is the duration a touch screen must be pressed
+	// before the press is considered a long-press.
+	LongPressThreshold time.Duration
+
+	// DragThreshold is the distance in pixels the cursor must be moved while
+	// pressed before a widget is dragged. This value is scaled.
+	DragThreshold int
+
+	// ScrollSpeed is the number of lines or list items scrolled per step of
+	// the mouse wheel.
+	ScrollSpeed float64
+
+	// MaxScroll is the maximum number of mouse wheel steps handled during a
+	// single update, limiting the large deltas reported by some touchpads. A
+	// value of 0 or less disables the limit.
+	MaxScroll float64
+
+	// TooltipDelay is the duration the mouse cursor must hover over a widget
+	// before its tooltip is shown.
+	TooltipDelay time.Duration
+
 	MoveLeftKeyboard  []ebiten.Key
 	MoveRightKeyboard []ebiten.Key
 	MoveDownKeyboard  []ebiten.Key
 	MoveUpKeyboard    []ebiten.Key
 
+	// Pressing a focus key moves focus to the next widget. Holding shift
+	// while pressing a focus key moves focus to the previous widget.
+	FocusNextKeyboard []ebiten.Key
+
 	MoveLeftGamepad  []ebiten.StandardGamepadButton
 	MoveRightGamepad []ebiten.StandardGamepadButton
 	MoveDownGamepad  []ebiten.StandardGamepadButton
 	MoveUpGamepad    []ebiten.StandardGamepadButton
 
+	// Holding a repeat key repeatedly passes the key to the focused widget.
+	// Repeating begins after KeyRepeatDelay and continues every
+	// KeyRepeatInterval. An interval of 0 or less disables repeating.
+	// Widgets may repeat additional keys by implementing KeyRepeater.
+	RepeatKeyboard    []ebiten.Key
+	KeyRepeatDelay    time.Duration
+	KeyRepeatInterval time.Duration
+
+	// The right mouse button is not a confirm mouse button by default, as it
+	// is the default context mouse button.
 	ConfirmKeyboard []ebiten.Key
 	ConfirmMouse    []ebiten.MouseButton
 	ConfirmGamepad  []ebiten.StandardGamepadButton
 
+	// Clicking a widget wrapped via WithContextMenu with a context mouse
+	// button shows its context menu.
+	ContextMouse []ebiten.MouseButton
+
 	// A sentinel rune value may be set for the confirm and back actions.
 	// This allows working around on-screen keyboard issues on Android.
 	ConfirmRune rune
@@ -33,18 +72,31 @@ type Shortcuts struct {
 // Bindings is the current keyboard, mouse and gamepad input configurations.
 var Bindings = &Shortcuts{
 	DoubleClickThreshold: 500 * time.Millisecond,
+	LongPressThreshold:   500 * time.Millisecond,
+	DragThreshold:        8,
+	ScrollSpeed:          3,
+	MaxScroll:            3,
+	TooltipDelay:         750 * time.Millisecond,
 
 	MoveLeftKeyboard:  []ebiten.Key{ebiten.KeyLeft},
 	MoveRightKeyboard: []ebiten.Key{ebiten.KeyRight},
 	MoveDownKeyboard:  []ebiten.Key{ebiten.KeyDown},
 	MoveUpKeyboard:    []ebiten.Key{ebiten.KeyUp},
 
+	FocusNextKeyboard: []ebiten.Key{ebiten.KeyTab},
+
 	MoveLeftGamepad:  []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftLeft},
 	MoveRightGamepad: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftRight},
 	MoveDownGamepad:  []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftBottom},
 	MoveUpGamepad:    []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftTop},
 
+	RepeatKeyboard:    []ebiten.Key{ebiten.KeyBackspace, ebiten.KeyDelete, ebiten.KeyLeft, ebiten.KeyRight, ebiten.KeyUp, ebiten.KeyDown, ebiten.KeyPageUp, ebiten.KeyPageDown},
+	KeyRepeatDelay:    500 * time.Millisecond,
+	KeyRepeatInterval: 75 * time.Millisecond,
+
 	ConfirmKeyboard: []ebiten.Key{ebiten.KeyEnter, ebiten.KeyKPEnter},
-	ConfirmMouse:    []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight},
+	ConfirmMouse:    []ebiten.MouseButton{ebiten.MouseButtonLeft},
 	ConfirmGamepad:  []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom},
+
+	ContextMouse: []ebiten.MouseButton{ebiten.MouseButtonRight},
 }
@@ -105,6 +105,7 @@ func (k *Keyboard) Cursor() ebiten.CursorShapeType {
 func (k *Keyboard) HandleMouse(cursor image.Point, pressed bool, clicked bool) (handled bool, err error) {
 	k.Lock()
 	defer k.Unlock()
+	k.k.SetRepeat(Bindings.KeyRepeatDelay, Bindings.KeyRepeatInterval)
 	return k.k.HandleMouse(cursor, pressed, clicked)
 }
 
@@ -113,10 +114,14 @@ func (k *Keyboard) Draw(screen *ebiten.Image) error {
 	k.Lock()
 	defer k.Unlock()
 	k.incoming = k.k.AppendInput(k.incoming[:0])
-	w := Focused()
-	if w != nil {
+	u := activeUI()
+	if u.focusedWidget != nil {
 		for _, key := range k.incoming {
-			_, err := w.HandleKeyboard(key.Key, key.Rune)
+			e := u.newKeyEvent(key.Key, 0)
+			if key.Rune > 0 {
+				e = u.newKeyEvent(-1, key.Rune)
+			}
+			_, err := u.dispatchKeyEvent(e)
 			if err != nil {
 				return err
 			}
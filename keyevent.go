@@ -0,0 +1,267 @@
+package etk
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// KeyEvent represents an extended keyboard event.
+type KeyEvent struct {
+	// Key is the key which was pressed or released. When a rune was entered,
+	// Key is -1.
+	Key ebiten.Key
+
+	// Rune is the rune which was entered. When a key was pressed or released,
+	// Rune is 0.
+	Rune rune
+
+	// Pressed is true when the key was pressed and false when it was released.
+	// Rune events are always pressed.
+	Pressed bool
+
+	// Repeat is true when the event was generated by holding down a key.
+	Repeat bool
+
+	// Modifier key states at the time of the event.
+	Ctrl  bool
+	Shift bool
+	Alt   bool
+	Meta  bool
+
+	// Target is the focused widget which the event is dispatched to. Widgets
+	// which capture or receive bubbled keyboard events may use Target to
+	// determine which descendant widget is focused.
+	Target Widget
+}
+
+// KeyEventHandler may be implemented by widgets to receive extended keyboard
+// events. Widgets which implement KeyEventHandler receive key presses, key
+// releases, repeated key presses and runes via HandleKeyEvent instead of
+// HandleKeyboard. Widgets which do not implement KeyEventHandler only receive
+// key presses and runes via HandleKeyboard.
+type KeyEventHandler interface {
+	// HandleKeyEvent is called when a keyboard event occurs.
+	HandleKeyEvent(e KeyEvent) (handled bool, err error)
+}
+
+// KeyCapturer may be implemented by widgets to receive keyboard events before
+// the focused widget when the focused widget is one of their descendants.
+// Capturing widgets are called in order from the root widget toward the
+// focused widget. When a capturing widget handles the event, the event is not
+// passed to the focused widget.
+type KeyCapturer interface {
+	// CaptureKeyEvent is called when a keyboard event occurs while a
+	// descendant widget is focused.
+	CaptureKeyEvent(e KeyEvent) (handled bool, err error)
+}
+
+// KeyBubbler may be implemented by widgets to receive keyboard events which
+// were not handled by the focused widget when the focused widget is one of
+// their descendants. Bubbled events are passed to each ancestor of the
+// focused widget in order from the focused widget toward the root widget,
+// until the event is handled. This allows container widgets to implement
+// keys such as navigation between their children and going back.
+type KeyBubbler interface {
+	// BubbleKeyEvent is called when a keyboard event was not handled by the
+	// focused descendant widget, or by a descendant of this widget which
+	// contains the focused widget.
+	BubbleKeyEvent(e KeyEvent) (handled bool, err error)
+}
+
+// newKeyEvent returns a key press event for the provided key or rune with the
+// current modifier key states.
+func (u *UI) newKeyEvent(key ebiten.Key, r rune) KeyEvent {
+	return KeyEvent{
+		Key:     key,
+		Rune:    r,
+		Pressed: true,
+		Ctrl:    u.input.IsKeyPressed(ebiten.KeyControl),
+		Shift:   u.input.IsKeyPressed(ebiten.KeyShift),
+		Alt:     u.input.IsKeyPressed(ebiten.KeyAlt),
+		Meta:    u.input.IsKeyPressed(ebiten.KeyMeta),
+	}
+}
+
+// newRuneEvent returns a keyboard event for the provided rune. Bindings.ConfirmRune
+// and Bindings.BackRune are converted into presses of the enter and backspace
+// keys.
+func (u *UI) newRuneEvent(r rune) KeyEvent {
+	switch r {
+	case Bindings.ConfirmRune:
+		return u.newKeyEvent(ebiten.KeyEnter, 0)
+	case Bindings.BackRune:
+		return u.newKeyEvent(ebiten.KeyBackspace, 0)
+	default:
+		return u.newKeyEvent(-1, r)
+	}
+}
+
+// handleKeyEvent passes a keyboard event to a widget. Key releases are only
+// passed to widgets which implement KeyEventHandler.
+func handleKeyEvent(w Widget, e KeyEvent) (handled bool, err error) {
+	MarkDirty(w)
+	handler, ok := w.(KeyEventHandler)
+	if ok {
+		return handler.HandleKeyEvent(e)
+	} else if !e.Pressed {
+		return false, nil
+	}
+	return w.HandleKeyboard(e.Key, e.Rune)
+}
+
+// dispatchKeyEvent passes a keyboard event to the ancestors of the focused
+// widget which capture keyboard events, then to the focused widget, and then to
+// the ancestors which receive bubbled keyboard events, until it is handled.
+func (u *UI) dispatchKeyEvent(e KeyEvent) (handled bool, err error) {
+	if u.focusedWidget == nil {
+		return false, nil
+	}
+	e.Target = u.focusedWidget
+
+	var ancestors []Widget
+	path, found := appendPath(u.keyPath[:0], u.activeRoot(), u.focusedWidget)
+	u.keyPath = path
+	if found {
+		ancestors = path[:len(path)-1]
+	}
+
+	for _, w := range ancestors {
+		capturer := findKeyCapturer(w)
+		if capturer == nil {
+			continue
+		}
+		handled, err := capturer.CaptureKeyEvent(e)
+		if err != nil || handled {
+			MarkDirty(w)
+			return handled, err
+		}
+	}
+
+	handled, err = handleKeyEvent(u.focusedWidget, e)
+	if err != nil || handled {
+		return handled, err
+	}
+
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		bubbler := findKeyBubbler(ancestors[i])
+		if bubbler == nil {
+			continue
+		}
+		handled, err := bubbler.BubbleKeyEvent(e)
+		if err != nil || handled {
+			MarkDirty(ancestors[i])
+			return handled, err
+		}
+	}
+	return false, nil
+}
+
+// appendPath appends the visible widgets from the provided widget to the
+// target widget, and returns whether the target widget was found. When the
+// target is not found, the path is returned unchanged.
+func appendPath(path []Widget, w Widget, target Widget) ([]Widget, bool) {
+	if w == nil || !w.Visible() {
+		return path, false
+	}
+	path = append(path, w)
+	if w == target {
+		return path, true
+	}
+	for _, child := range w.Children() {
+		result, found := appendPath(path, child, target)
+		if found {
+			return result, true
+		}
+	}
+	return path[:len(path)-1], false
+}
+
+// findKeyCapturer returns the provided widget, or the widget wrapped by it, as
+// a KeyCapturer.
+func findKeyCapturer(w Widget) KeyCapturer {
+	for ; w != nil; w = unwrap(w) {
+		capturer, ok := w.(KeyCapturer)
+		if ok {
+			return capturer
+		}
+	}
+	return nil
+}
+
+// findKeyBubbler returns the provided widget, or the widget wrapped by it, as
+// a KeyBubbler.
+func findKeyBubbler(w Widget) KeyBubbler {
+	for ; w != nil; w = unwrap(w) {
+		bubbler, ok := w.(KeyBubbler)
+		if ok {
+			return bubbler
+		}
+	}
+	return nil
+}
+
+// KeyRepeater may be implemented by widgets to repeat additional keys while
+// the widget is focused. See Shortcuts.RepeatKeyboard.
+type KeyRepeater interface {
+	// RepeatKeys returns the keys which are repeated while held down, in
+	// addition to the keys specified in Bindings.
+	RepeatKeys() []ebiten.Key
+}
+
+// repeatable returns whether the provided key is repeated while held down.
+func (u *UI) repeatable(key ebiten.Key) bool {
+	for _, k := range Bindings.RepeatKeyboard {
+		if key == k {
+			return true
+		}
+	}
+	repeater, ok := u.focusedWidget.(KeyRepeater)
+	if !ok {
+		return false
+	}
+	for _, k := range repeater.RepeatKeys() {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// handleKeyRepeat passes repeated key presses to the focused widget. Only the
+// most recently pressed repeatable key is repeated.
+func (u *UI) handleKeyRepeat(pressed []ebiten.Key) error {
+	for _, key := range pressed {
+		if u.repeatable(key) {
+			u.repeatKey = key
+			u.nextRepeat = time.Now().Add(Bindings.KeyRepeatDelay)
+			return nil
+		}
+	}
+	if u.repeatKey == -1 {
+		return nil
+	} else if !u.input.IsKeyPressed(u.repeatKey) || Bindings.KeyRepeatInterval <= 0 {
+		u.repeatKey = -1
+		return nil
+	}
+
+	now := time.Now()
+	if now.Before(u.nextRepeat) {
+		return nil
+	}
+	u.nextRepeat = u.nextRepeat.Add(Bindings.KeyRepeatInterval)
+	if u.nextRepeat.Before(now) {
+		u.nextRepeat = now.Add(Bindings.KeyRepeatInterval)
+	}
+
+	e := u.newKeyEvent(u.repeatKey, 0)
+	e.Repeat = true
+	var err error
+	d, ok := directionKey(u.repeatKey)
+	if ok {
+		_, err = u.handleDirection(d, e)
+	} else {
+		_, err = u.dispatchKeyEvent(e)
+	}
+	return err
+}
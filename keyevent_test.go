@@ -0,0 +1,65 @@
+package etk_test
+
+import (
+	"testing"
+
+	"codeberg.org/tslocum/etk"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type keyContainer struct {
+	*etk.Grid
+	capture  ebiten.Key
+	captured []ebiten.Key
+	bubbled  []ebiten.Key
+	targets  []etk.Widget
+}
+
+func (c *keyContainer) CaptureKeyEvent(e etk.KeyEvent) (handled bool, err error) {
+	if e.Key != c.capture || !e.Pressed {
+		return false, nil
+	}
+	c.captured = append(c.captured, e.Key)
+	return true, nil
+}
+
+func (c *keyContainer) BubbleKeyEvent(e etk.KeyEvent) (handled bool, err error) {
+	if !e.Pressed {
+		return false, nil
+	}
+	c.bubbled = append(c.bubbled, e.Key)
+	c.targets = append(c.targets, e.Target)
+	return true, nil
+}
+
+type keyIgnoringWidget struct {
+	*testWidget
+}
+
+func (w *keyIgnoringWidget) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
+	w.testWidget.HandleKeyboard(key, r)
+	return key != ebiten.KeyEscape, nil
+}
+
+func TestKeyEventPropagation(t *testing.T) {
+	w := &keyIgnoringWidget{testWidget: newTestWidget()}
+	c := &keyContainer{Grid: etk.NewGrid(), capture: ebiten.KeyF1}
+	c.AddChildAt(w, 0, 0, 1, 1)
+	u, f, _, _ := setupFakeInput(t, c)
+	u.SetFocus(w)
+
+	for _, key := range []ebiten.Key{ebiten.KeyA, ebiten.KeyEscape, ebiten.KeyF1} {
+		f.PressKey(key)
+		frame(t, u, f)
+		f.ReleaseKey(key)
+		frame(t, u, f)
+	}
+
+	if len(w.keys) != 2 || w.keys[0] != ebiten.KeyA || w.keys[1] != ebiten.KeyEscape {
+		t.Fatalf("unexpected keys received by focused widget: %v", w.keys)
+	} else if len(c.bubbled) != 1 || c.bubbled[0] != ebiten.KeyEscape || c.targets[0] != w {
+		t.Fatalf("unexpected bubbled keys: %v", c.bubbled)
+	} else if len(c.captured) != 1 || c.captured[0] != ebiten.KeyF1 {
+		t.Fatalf("unexpected captured keys: %v", c.captured)
+	}
+}
@@ -340,7 +340,7 @@ func (k *Keyboard) HandleMouse(cursor image.Point, pressed bool, clicked bool) (
 				input = key.UpperInput
 			}
 			if input.Key == ebiten.KeyBackspace || input.Key == ebiten.KeyDelete {
-				for time.Since(key.repeatTime) >= k.backspaceRepeat {
+				for k.backspaceRepeat > 0 && time.Since(key.repeatTime) >= k.backspaceRepeat {
 					k.inputEvents = append(k.inputEvents, &Input{Key: input.Key})
 					key.repeatTime = key.repeatTime.Add(k.backspaceRepeat)
 				}
@@ -445,7 +445,7 @@ func (k *Keyboard) Update() error {
 
 				// Repeat backspace and delete operations.
 				if input.Key == ebiten.KeyBackspace || input.Key == ebiten.KeyDelete {
-					for time.Since(key.repeatTime) >= k.backspaceRepeat {
+					for k.backspaceRepeat > 0 && time.Since(key.repeatTime) >= k.backspaceRepeat {
 						k.inputEvents = append(k.inputEvents, &Input{Key: input.Key})
 						key.repeatTime = key.repeatTime.Add(k.backspaceRepeat)
 					}
@@ -517,7 +517,7 @@ func (k *Keyboard) Update() error {
 				} else {
 					// Repeat backspace and delete operations.
 					if input.Key == ebiten.KeyBackspace || input.Key == ebiten.KeyDelete {
-						for time.Since(k.backspaceLast) >= k.backspaceRepeat {
+						for k.backspaceRepeat > 0 && time.Since(k.backspaceLast) >= k.backspaceRepeat {
 							k.inputEvents = append(k.inputEvents, &Input{Key: input.Key})
 							k.backspaceLast = k.backspaceLast.Add(k.backspaceRepeat)
 						}
@@ -664,6 +664,14 @@ func (k *Keyboard) SetPassThroughPhysicalInput(pass bool) {
 	k.passPhysical = pass
 }
 
+// SetRepeat sets the delay before a held backspace or delete key begins to
+// repeat and the interval between each repeat. An interval of 0 or less
+// disables repeating.
+func (k *Keyboard) SetRepeat(delay time.Duration, interval time.Duration) {
+	k.backspaceDelay = delay
+	k.backspaceRepeat = interval
+}
+
 // SetAlpha sets the transparency level of the widget on a scale of 0 to 1.0.
 func (k *Keyboard) SetAlpha(alpha float64) {
 	k.alpha = alpha
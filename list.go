@@ -32,12 +32,15 @@ type List struct {
 	focused              bool
 	itemHeight           int
 	highlightColor       color.RGBA
+	hoverColor           color.RGBA
+	hoverY               int
 	maxY                 int
 	selectionMode        SelectionMode
 	selectedX, selectedY int
 	selectedTime         time.Time
 	onChange             func(index int) (accept bool)
 	onConfirm            func(index int)
+	onReorder            func(from int, to int) (accept bool)
 	items                [][]Widget
 	offset               int
 	recreateGrid         bool
@@ -52,6 +55,7 @@ type List struct {
 	scrollBorderLeft     color.RGBA
 	scrollDrag           bool
 	drawBorder           bool
+	tabOrder             int
 	sync.Mutex
 }
 
@@ -73,6 +77,8 @@ func NewList(itemHeight int, onChange func(index int) (accept bool), onConfirm f
 		grid:               NewGrid(),
 		itemHeight:         itemHeight,
 		highlightColor:     color.RGBA{128, 128, 128, 255},
+		hoverColor:         Style.ListHoverColor,
+		hoverY:             -1,
 		maxY:               -1,
 		selectionMode:      SelectRow,
 		selectedX:          -1,
@@ -144,6 +150,14 @@ func (l *List) SetFocus(focus bool) (accept bool) {
 	return true
 }
 
+func (l *List) tabIndex() int {
+	return l.tabOrder
+}
+
+func (l *List) setTabIndex(index int) {
+	l.tabOrder = index
+}
+
 // Visible returns the visibility of the widget.
 func (l *List) Visible() bool {
 	l.Lock()
@@ -215,6 +229,27 @@ func (l *List) SetHighlightColor(c color.RGBA) {
 	l.highlightColor = c
 }
 
+// SetHoverColor sets the color used to highlight the item under the mouse
+// cursor. A transparent color disables hover highlighting.
+func (l *List) SetHoverColor(c color.RGBA) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.hoverColor = c
+	MarkDirty(l)
+}
+
+// MouseEnter is called when the mouse cursor enters the widget.
+func (l *List) MouseEnter() {}
+
+// MouseLeave is called when the mouse cursor leaves the widget.
+func (l *List) MouseLeave() {
+	l.Lock()
+	defer l.Unlock()
+
+	l.hoverY = -1
+}
+
 // SelectedItem returns the selected list item.
 func (l *List) SelectedItem() (x int, y int) {
 	l.Lock()
@@ -229,6 +264,7 @@ func (l *List) SetSelectedItem(x int, y int) {
 	defer l.Unlock()
 
 	l.selectedX, l.selectedY = x, y
+	MarkDirty(l)
 }
 
 // SetScrollBarWidth sets the width of the scroll bar.
@@ -290,6 +326,18 @@ func (l *List) SetConfirmFunc(onConfirm func(index int)) {
 	l.onConfirm = onConfirm
 }
 
+// SetReorderFunc sets a handler which is called when a list item is dragged
+// to a new position. Items may only be reordered by dragging when a handler
+// is set. Providing a nil function value will remove the existing handler
+// (if set). The handler may return false to keep the item at its original
+// position.
+func (l *List) SetReorderFunc(onReorder func(from int, to int) (accept bool)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.onReorder = onReorder
+}
+
 // Children returns the children of the widget. Children are drawn in the
 // order they are returned. Keyboard and mouse events are passed to children
 // in reverse order.
@@ -321,6 +369,7 @@ func (l *List) AddChildAt(w Widget, x int, y int) {
 		l.maxY = y
 		l.recreateGrid = true
 	}
+	MarkDirty(l)
 }
 
 // Rows returns the number of rows in the list.
@@ -352,6 +401,46 @@ func (l *List) Cursor() ebiten.CursorShapeType {
 	return ebiten.CursorShapeDefault
 }
 
+// Accessibility returns the role, name, value and state of the widget.
+func (l *List) Accessibility() Accessibility {
+	l.Lock()
+	defer l.Unlock()
+
+	return Accessibility{
+		Role:    RoleList,
+		Focused: l.focused,
+	}
+}
+
+// accessibleChildren returns an accessible node for each row of the list.
+func (l *List) accessibleChildren(walk func(w Widget) []*AccessibleNode) []*AccessibleNode {
+	l.Lock()
+	items := append([][]Widget(nil), l.items...)
+	rect, itemHeight, offset := l.rect, l.itemHeight, l.offset
+	selectedY := -1
+	if l.selectionMode != SelectNone {
+		selectedY = l.selectedY
+	}
+	l.Unlock()
+
+	nodes := make([]*AccessibleNode, len(items))
+	for y, row := range items {
+		node := &AccessibleNode{
+			Accessibility: Accessibility{
+				Role:     RoleListItem,
+				Selected: y == selectedY,
+			},
+			Rect: image.Rect(rect.Min.X, rect.Min.Y+y*itemHeight-offset, rect.Max.X, rect.Min.Y+(y+1)*itemHeight-offset),
+		}
+		for _, w := range row {
+			node.Children = append(node.Children, walk(w)...)
+		}
+		node.Name = accessibleName(node.Children)
+		nodes[y] = node
+	}
+	return nodes
+}
+
 // HandleKeyboard is called when a keyboard event occurs.
 func (l *List) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
 	l.Lock()
@@ -371,35 +460,34 @@ func (l *List) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error)
 			}
 		}
 
-		// Handle movement.
-		move := func(x int, y int) {
+		// Handle movement. Movement beyond the first or last item is not
+		// handled, allowing focus to move to a neighboring widget.
+		move := func(x int, y int) bool {
 			y = l.selectedY + y
-			if y >= 0 && y <= l.maxY {
-				l.selectedY = y
+			if y < 0 || y > l.maxY || y == l.selectedY {
+				return false
 			}
+			l.selectedY = y
+			return true
 		}
 		for _, leftKey := range Bindings.MoveLeftKeyboard {
 			if key == leftKey {
-				move(-1, 0)
-				return true, nil
+				return move(-1, 0), nil
 			}
 		}
 		for _, rightKey := range Bindings.MoveRightKeyboard {
 			if key == rightKey {
-				move(1, 0)
-				return true, nil
+				return move(1, 0), nil
 			}
 		}
 		for _, downKey := range Bindings.MoveDownKeyboard {
 			if key == downKey {
-				move(0, 1)
-				return true, nil
+				return move(0, 1), nil
 			}
 		}
 		for _, upKey := range Bindings.MoveUpKeyboard {
 			if key == upKey {
-				move(0, -1)
-				return true, nil
+				return move(0, -1), nil
 			}
 		}
 	}
@@ -418,20 +506,6 @@ func (l *List) HandleMouse(cursor image.Point, pressed bool, clicked bool) (hand
 	l.Lock()
 	defer l.Unlock()
 
-	_, scroll := ebiten.Wheel()
-	if scroll != 0 {
-		if scroll < -maxScroll {
-			scroll = -maxScroll
-		} else if scroll > maxScroll {
-			scroll = maxScroll
-		}
-		offset := l.clampOffset(l.offset - int(math.Round(scroll))*3*l.itemHeight)
-		if offset != l.offset {
-			l.offset = offset
-			l.recreateGrid = true
-		}
-	}
-
 	if l.showScrollBar() && (pressed || l.scrollDrag) {
 		if pressed && cursor.In(l.scrollRect) {
 			dragY := cursor.Y - l.rect.Min.Y
@@ -461,6 +535,18 @@ func (l *List) HandleMouse(cursor image.Point, pressed bool, clicked bool) (hand
 		}
 	}
 
+	hoverY := -1
+	if cursor.In(l.rect) && !(l.showScrollBar() && cursor.In(l.scrollRect)) {
+		hoverY = (l.offset + cursor.Y - l.rect.Min.Y) / l.itemHeight
+		if hoverY > l.maxY {
+			hoverY = -1
+		}
+	}
+	if hoverY != l.hoverY {
+		l.hoverY = hoverY
+		MarkDirty(l)
+	}
+
 	if !clicked || (cursor.X == 0 && cursor.Y == 0) {
 		return true, nil
 	}
@@ -494,6 +580,40 @@ func (l *List) HandleMouse(cursor image.Point, pressed bool, clicked bool) (hand
 	return true, nil
 }
 
+// ScrollOffset returns the distance in pixels which the list is scrolled from
+// the top.
+func (l *List) ScrollOffset() int {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.offset
+}
+
+// SetScrollOffset sets the distance in pixels which the list is scrolled from
+// the top. See AnimateScroll.
+func (l *List) SetScrollOffset(offset int) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.offset = l.clampOffset(offset)
+	l.recreateGrid = true
+	MarkDirty(l)
+}
+
+// HandleScroll is called when the mouse wheel is scrolled over the widget.
+func (l *List) HandleScroll(x float64, y float64) (handled bool, err error) {
+	l.Lock()
+	defer l.Unlock()
+
+	offset := l.clampOffset(l.offset - int(math.Round(y*float64(l.itemHeight))))
+	if offset == l.offset {
+		return false, nil
+	}
+	l.offset = offset
+	l.recreateGrid = true
+	return true, nil
+}
+
 func (l *List) _recreateCrid(screen *ebiten.Image) {
 	maxY := l.rect.Dy()/l.itemHeight + 1
 	if maxY < 2 {
@@ -550,6 +670,16 @@ func (l *List) Draw(screen *ebiten.Image) error {
 		return err
 	}
 
+	// Highlight item under mouse cursor.
+	if l.selectionMode != SelectNone && l.hoverY >= 0 && l.hoverY != l.selectedY && l.hoverColor.A != 0 {
+		x, y := l.rect.Min.X, l.rect.Min.Y+l.hoverY*l.itemHeight-l.offset
+		w, h := l.rect.Dx(), l.itemHeight
+		r := clampRect(image.Rect(x, y, x+w, y+h), l.rect)
+		if r.Dx() > 0 && r.Dy() > 0 {
+			screen.SubImage(r).(*ebiten.Image).Fill(l.hoverColor)
+		}
+	}
+
 	// Highlight selection.
 	drawHighlight := l.selectionMode != SelectNone && l.selectedY >= 0
 	if drawHighlight {
@@ -612,4 +742,92 @@ func (l *List) Clear() {
 	l.selectedX, l.selectedY = 0, -1
 	l.offset = 0
 	l.recreateGrid = true
+	MarkDirty(l)
+}
+
+// listDragItem is the payload of a list item being dragged.
+type listDragItem struct {
+	list  *List
+	index int
+}
+
+// DragStart is called when the widget begins to be dragged. List items may
+// only be dragged when a reorder handler is set.
+func (l *List) DragStart(cursor image.Point) (payload any, preview *ebiten.Image) {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.onReorder == nil || (l.showScrollBar() && cursor.In(l.scrollRect)) {
+		return nil, nil
+	}
+	index := (l.offset + cursor.Y - l.rect.Min.Y) / l.itemHeight
+	if index < 0 || index > l.maxY || l.rect.Dx() <= 0 {
+		return nil, nil
+	}
+	preview = ebiten.NewImage(l.rect.Dx(), l.itemHeight)
+	preview.Fill(l.highlightColor)
+	return &listDragItem{list: l, index: index}, preview
+}
+
+// DragEnd is called after the payload is dropped.
+func (l *List) DragEnd(payload any, accepted bool) error {
+	return nil
+}
+
+// DragEnter is called when a payload is dragged onto the widget.
+func (l *List) DragEnter(payload any, cursor image.Point) {}
+
+// DragOver is called each frame a payload is dragged over the widget.
+func (l *List) DragOver(payload any, cursor image.Point) {}
+
+// DragLeave is called when a payload is dragged off of the widget.
+func (l *List) DragLeave(payload any) {}
+
+// Drop is called when a payload is dropped onto the widget. Items dragged
+// from the same list are moved to the position where they are dropped.
+func (l *List) Drop(payload any, cursor image.Point) (accept bool, err error) {
+	l.Lock()
+	defer l.Unlock()
+
+	item, ok := payload.(*listDragItem)
+	if !ok || item.list != l || l.onReorder == nil || item.index > l.maxY {
+		return false, nil
+	}
+	from := item.index
+	to := (l.offset + cursor.Y - l.rect.Min.Y) / l.itemHeight
+	if to < 0 {
+		to = 0
+	} else if to > l.maxY {
+		to = l.maxY
+	}
+	if to == from {
+		return false, nil
+	}
+
+	onReorder := l.onReorder
+	l.Unlock()
+	accept = onReorder(from, to)
+	l.Lock()
+	if !accept {
+		return false, nil
+	}
+
+	row := l.items[from]
+	if from < to {
+		copy(l.items[from:to], l.items[from+1:to+1])
+	} else {
+		copy(l.items[to+1:from+1], l.items[to:from])
+	}
+	l.items[to] = row
+
+	switch {
+	case l.selectedY == from:
+		l.selectedY = to
+	case from < to && l.selectedY > from && l.selectedY <= to:
+		l.selectedY--
+	case from > to && l.selectedY >= to && l.selectedY < from:
+		l.selectedY++
+	}
+	l.recreateGrid = true
+	return true, nil
 }
@@ -0,0 +1,122 @@
+package messeji
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// clusterStart returns the position of the start of the grapheme cluster
+// which contains the provided position within the text. Grapheme clusters are
+// user-perceived characters, such as a letter followed by combining marks, an
+// emoji sequence joined by zero-width joiners or a flag.
+func clusterStart(text string, pos int) int {
+	pos = max(0, min(pos, len(text)))
+	// Grapheme clusters never continue after a line feed.
+	start := strings.LastIndexByte(text[:pos], '\n') + 1
+	rest := text[start:]
+	state := -1
+	var cluster string
+	for start < pos {
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if cluster == "" || start+len(cluster) > pos {
+			break
+		}
+		start += len(cluster)
+	}
+	return start
+}
+
+// prevBoundary returns the position of the grapheme cluster before the
+// provided position within the text.
+func prevBoundary(text string, pos int) int {
+	if pos <= 0 {
+		return 0
+	}
+	return clusterStart(text, pos-1)
+}
+
+// nextBoundary returns the position of the grapheme cluster after the
+// provided position within the text.
+func nextBoundary(text string, pos int) int {
+	if pos >= len(text) {
+		return len(text)
+	}
+	cluster, _, _, _ := uniseg.FirstGraphemeClusterInString(text[pos:], -1)
+	return pos + len(cluster)
+}
+
+// isWordRune returns whether the provided rune is part of a word.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_'
+}
+
+// prevWord returns the position of the start of the word before the provided
+// position within the text.
+func prevWord(text string, pos int) int {
+	pos = min(pos, len(text))
+	for pos > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:pos])
+		if isWordRune(r) {
+			break
+		}
+		pos -= size
+	}
+	for pos > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:pos])
+		if !isWordRune(r) {
+			break
+		}
+		pos -= size
+	}
+	return pos
+}
+
+// nextWord returns the position of the end of the word after the provided
+// position within the text.
+func nextWord(text string, pos int) int {
+	pos = max(pos, 0)
+	for pos < len(text) {
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		if isWordRune(r) {
+			break
+		}
+		pos += size
+	}
+	for pos < len(text) {
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		if !isWordRune(r) {
+			break
+		}
+		pos += size
+	}
+	return pos
+}
+
+// wordAt returns the bounds of the word at the provided position within the
+// text. When there is no word at the position, the bounds of the grapheme
+// cluster at the position are returned.
+func wordAt(text string, pos int) (start int, end int) {
+	pos = max(0, min(pos, len(text)))
+	start, end = pos, pos
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:start])
+		if !isWordRune(r) {
+			break
+		}
+		start -= size
+	}
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(r) {
+			break
+		}
+		end += size
+	}
+	if start == end && end < len(text) {
+		end = nextBoundary(text, end)
+	}
+	return start, end
+}
@@ -0,0 +1,200 @@
+package messeji
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultHistorySize is the default maximum number of edits which may be
+// undone.
+const defaultHistorySize = 100
+
+// editKind is the type of an edit, which determines how edits are grouped in
+// the edit history.
+type editKind int
+
+const (
+	// editOther is an edit which is never grouped, such as pasting text.
+	editOther editKind = iota
+
+	// editTyping is an edit made by entering a rune.
+	editTyping
+
+	// editDeleting is an edit made by pressing backspace or delete.
+	editDeleting
+)
+
+// historyEntry is an edit which may be undone and redone.
+type historyEntry struct {
+	// pos is the position of the edit, in bytes from the start of the text.
+	pos int
+
+	// removed is the text which was removed by the edit.
+	removed string
+
+	// inserted is the text which was inserted by the edit.
+	inserted string
+
+	// caret and anchor are the bounds of the selection before the edit.
+	caret  int
+	anchor int
+
+	kind editKind
+}
+
+// merge groups the provided edit with the entry when the edit continues it,
+// such as when typing a word or holding backspace.
+func (e *historyEntry) merge(next historyEntry) bool {
+	if next.kind != e.kind {
+		return false
+	}
+	switch e.kind {
+	case editTyping:
+		if next.removed != "" || next.pos != e.pos+len(e.inserted) {
+			return false
+		}
+		// Start a new group at the beginning of each word.
+		last, _ := utf8.DecodeLastRuneInString(e.inserted)
+		first, _ := utf8.DecodeRuneInString(next.inserted)
+		if unicode.IsSpace(last) && !unicode.IsSpace(first) {
+			return false
+		}
+		e.inserted += next.inserted
+		return true
+	case editDeleting:
+		if e.inserted != "" || next.inserted != "" {
+			return false
+		} else if next.pos+len(next.removed) == e.pos {
+			// Backspace.
+			e.pos = next.pos
+			e.removed = next.removed + e.removed
+			return true
+		} else if next.pos == e.pos {
+			// Delete.
+			e.removed += next.removed
+			return true
+		}
+	}
+	return false
+}
+
+// SetHistorySize sets the maximum number of edits which may be undone. The
+// oldest edits are discarded when the limit is reached. A size of 0 disables
+// the edit history. The default size is 100.
+func (f *InputField) SetHistorySize(size int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.historySize = max(0, size)
+	if len(f.history) > f.historySize {
+		discard := len(f.history) - f.historySize
+		f.history = append(f.history[:0], f.history[discard:]...)
+		f.historyIndex = max(0, f.historyIndex-discard)
+	}
+}
+
+// SetClearHistoryOnSetText sets whether the edit history is cleared when the
+// text is set via SetText, or cleared after the enter key is pressed. When
+// disabled, setting the text is recorded as an edit which may be undone. The
+// history is cleared by default.
+func (f *InputField) SetClearHistoryOnSetText(clear bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.clearHistory = clear
+}
+
+// ClearHistory clears the edit history.
+func (f *InputField) ClearHistory() {
+	f.Lock()
+	defer f.Unlock()
+
+	f.history = f.history[:0]
+	f.historyIndex = 0
+}
+
+// Undo undoes the last edit. It returns whether an edit was undone.
+func (f *InputField) Undo() bool {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.undo()
+}
+
+// Redo redoes the last edit which was undone. It returns whether an edit was
+// redone.
+func (f *InputField) Redo() bool {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.redo()
+}
+
+// SetText sets the text in the field. See SetClearHistoryOnSetText.
+func (f *InputField) SetText(text string) {
+	f.Lock()
+	f.setTextHistory(text)
+	f.Unlock()
+
+	f.TextField.SetText(text)
+}
+
+// setTextHistory clears the edit history, or records the text being replaced
+// with the provided text, as the text is set.
+func (f *InputField) setTextHistory(text string) {
+	if f.clearHistory {
+		f.history = f.history[:0]
+		f.historyIndex = 0
+		return
+	}
+	f.processIncoming()
+	current := f.joinedText()
+	if current == text {
+		return
+	}
+	f.record(historyEntry{removed: current, inserted: text, caret: f.caret, anchor: f.anchor})
+}
+
+// record adds an edit to the history, discarding any edits which were undone.
+func (f *InputField) record(e historyEntry) {
+	if f.historySize == 0 {
+		return
+	}
+	f.history = f.history[:f.historyIndex]
+	n := len(f.history)
+	if n == 0 || !f.history[n-1].merge(e) {
+		f.history = append(f.history, e)
+		if len(f.history) > f.historySize {
+			f.history = append(f.history[:0], f.history[1:]...)
+		}
+	}
+	f.historyIndex = len(f.history)
+}
+
+func (f *InputField) undo() bool {
+	if f.historyIndex == 0 {
+		return false
+	}
+	e := f.history[f.historyIndex-1]
+	if !f.confirm(e.pos, e.pos+len(e.inserted), e.removed, 0) {
+		return false
+	}
+	f.replace(e.pos, e.pos+len(e.inserted), e.removed)
+	f.setCaret(e.caret)
+	f.anchor = e.anchor
+	f.historyIndex = max(0, f.historyIndex-1)
+	return true
+}
+
+func (f *InputField) redo() bool {
+	if f.historyIndex == len(f.history) {
+		return false
+	}
+	e := f.history[f.historyIndex]
+	if !f.confirm(e.pos, e.pos+len(e.removed), e.inserted, 0) {
+		return false
+	}
+	f.replace(e.pos, e.pos+len(e.removed), e.inserted)
+	f.historyIndex = min(f.historyIndex+1, len(f.history))
+	return true
+}
@@ -1,6 +1,8 @@
 package messeji
 
 import (
+	"image"
+	"strings"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -20,10 +22,18 @@ type InputField struct {
 	// The function may return false to skip adding the rune to the text buffer.
 	changedFunc func(r rune) (accept bool)
 
+	// editFunc is a function which is called when the text buffer is edited
+	// by the user. The function may return false to skip the edit.
+	editFunc func(text string, r rune) (accept bool)
+
 	// selectedFunc is a function which is called when the enter key is pressed. The
 	// function may return true to clear the text buffer.
 	selectedFunc func() (accept bool)
 
+	// modifierFunc is a function which returns the modifier keys which are
+	// currently pressed, or nil to read them from Ebitengine.
+	modifierFunc func() Modifier
+
 	// readBuffer is where incoming runes are stored before being added to the input buffer.
 	readBuffer []rune
 
@@ -36,19 +46,88 @@ type InputField struct {
 	// rawKeyBuffer is where incoming raw keys are stored before being added to the input buffer.
 	rawKeyBuffer []ebiten.Key
 
+	// history is the list of edits which may be undone and redone.
+	history []historyEntry
+
+	// historyIndex is the number of edits in the history which have not been
+	// undone.
+	historyIndex int
+
+	// historySize is the maximum number of edits in the history.
+	historySize int
+
+	// clearHistory is whether the history is cleared when the text is set.
+	clearHistory bool
+
+	// indent is the text inserted when the tab key is pressed, or an empty
+	// string when the tab key is not handled.
+	indent string
+
 	sync.Mutex
 }
 
 // NewInputField returns a new InputField. See type documentation for more info.
 func NewInputField(fontSource *text.GoTextFaceSource, fontSize int, fontMutex *sync.Mutex) *InputField {
 	f := &InputField{
-		TextField: NewTextField(fontSource, fontSize, fontMutex),
+		TextField:    NewTextField(fontSource, fontSize, fontMutex),
+		historySize:  defaultHistorySize,
+		clearHistory: true,
 	}
 	f.TextField.SetFollow(true)
-	f.TextField.suffix = "_"
+	f.TextField.editable = true
+	f.TextField.caretVisible = true
 	return f
 }
 
+// Modifier is a set of modifier keys held while a key is pressed.
+type Modifier int
+
+// Modifier keys.
+const (
+	ModifierShift Modifier = 1 << iota
+	ModifierControl
+	ModifierAlt
+	ModifierMeta
+)
+
+// SetModifierFunc sets a function which returns the modifier keys which are
+// currently pressed. The modifier keys are passed along with keys handled via
+// HandleKeyboardEvent and Update. Providing a nil function value reads the
+// modifier keys from Ebitengine, which is the default.
+func (f *InputField) SetModifierFunc(modifierFunc func() Modifier) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.modifierFunc = modifierFunc
+}
+
+// modifiers returns the modifier keys which are currently pressed.
+func (f *InputField) modifiers() Modifier {
+	if f.modifierFunc != nil {
+		return f.modifierFunc()
+	}
+	return pressedModifiers()
+}
+
+// pressedModifiers returns the modifier keys which are currently pressed
+// according to Ebitengine.
+func pressedModifiers() Modifier {
+	var mods Modifier
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		mods |= ModifierShift
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyControl) {
+		mods |= ModifierControl
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyAlt) {
+		mods |= ModifierAlt
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyMeta) {
+		mods |= ModifierMeta
+	}
+	return mods
+}
+
 // SetHandleKeyboard sets a flag controlling whether keyboard input should be handled
 // by the field. This can be used to facilitate focus changes between multiple inputs.
 func (f *InputField) SetHandleKeyboard(handle bool) {
@@ -64,6 +143,28 @@ func (f *InputField) SetChangedFunc(changedFunc func(r rune) (accept bool)) {
 	f.changedFunc = changedFunc
 }
 
+// SetEditFunc sets a handler which is called when the text buffer is edited by
+// the user, such as when a rune is entered or text is deleted. The text as it
+// will be after the edit is passed to the handler, along with the rune which
+// was entered, or 0 when text is deleted. The handler may return false to skip
+// the edit.
+func (f *InputField) SetEditFunc(editFunc func(text string, r rune) (accept bool)) {
+	f.editFunc = editFunc
+}
+
+// SetIndent sets the text inserted when the tab key is pressed in multi-line
+// mode, such as a tab character or a number of spaces. When multiple lines are
+// selected, each line is indented. Shift+Tab removes one level of indentation
+// from the selected lines, and new lines keep the indentation of the line
+// before them. Set to an empty string to leave the tab key unhandled, which is
+// the default.
+func (f *InputField) SetIndent(indent string) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.indent = indent
+}
+
 // SetSelectedFunc sets a handler which is called when the enter key is pressed.
 // Providing a nil function value will remove the existing handler (if set).
 // The handler may return true to clear the text buffer.
@@ -71,8 +172,84 @@ func (f *InputField) SetSelectedFunc(selectedFunc func() (accept bool)) {
 	f.selectedFunc = selectedFunc
 }
 
-// HandleKeyboardEvent passes the provided key or rune to the Inputfield.
+// Composition returns the text being composed via an input method editor.
+func (f *InputField) Composition() string {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.composition
+}
+
+// SetComposition sets the text being composed via an input method editor,
+// such as when entering Chinese, Japanese or Korean text. The composition is
+// shown underlined at the insertion point until it is committed, at which point
+// the composed text is passed to the field as runes and the composition is
+// cleared by setting an empty string.
+func (f *InputField) SetComposition(text string) {
+	f.Lock()
+	defer f.Unlock()
+
+	if text == f.composition {
+		return
+	}
+	f.composition = text
+	f.needWrap = 0
+	f.wrapStart = 0
+	f.modified = true
+	f.resizeFont()
+}
+
+// CaretRect returns the location of the insertion point on the screen as of
+// the last time the field was drawn. Input method editors show their
+// candidate windows near this location.
+func (f *InputField) CaretRect() image.Rectangle {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.caretRect.Empty() {
+		return image.Rectangle{Min: f.r.Min, Max: f.r.Min.Add(image.Point{1, f.r.Dy()})}
+	}
+	return f.caretRect.Add(f.r.Min)
+}
+
+// SetCaretVisible sets whether the caret is drawn. The caret is only drawn
+// while keyboard events are handled by the field.
+func (f *InputField) SetCaretVisible(visible bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.caretVisible = visible
+	f.redraw = true
+}
+
+// Write writes to the field at the insertion point, replacing the selected
+// text.
+func (f *InputField) Write(p []byte) (n int, err error) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.processIncoming()
+	start, end := f.selection()
+	f.record(historyEntry{pos: start, removed: f.joinedText()[start:end], inserted: string(p), caret: f.caret, anchor: f.anchor})
+	f.replace(start, end, string(p))
+	f.resizeFont()
+	return len(p), nil
+}
+
+// HandleKeyboardEvent passes the provided key or rune to the Inputfield. The
+// modifier keys which are currently pressed are passed along with the key.
+// See SetModifierFunc.
 func (f *InputField) HandleKeyboardEvent(key ebiten.Key, r rune) (handled bool, err error) {
+	f.Lock()
+	mods := f.modifiers()
+	f.Unlock()
+
+	return f.HandleKeyEvent(key, r, mods)
+}
+
+// HandleKeyEvent passes the provided key or rune, and the modifier keys held
+// while it was pressed, to the InputField.
+func (f *InputField) HandleKeyEvent(key ebiten.Key, r rune, mods Modifier) (handled bool, err error) {
 	f.Lock()
 	defer f.Unlock()
 
@@ -94,95 +271,304 @@ func (f *InputField) HandleKeyboardEvent(key ebiten.Key, r rune) (handled bool,
 	}
 
 	// Handle key event.
-	ok := f.handleKeys([]ebiten.Key{key})
-	if ok {
+	handled = f.handleKey(key, mods)
+	if handled {
 		f.resizeFont()
 	}
-	return true, nil
+	return handled, nil
+}
+
+// edit replaces the text between the provided positions after confirming the
+// change with the changed and edit handlers, and records it in the history.
+// The rune which was entered is passed to the handlers, or 0 when text is
+// deleted.
+func (f *InputField) edit(start int, end int, text string, r rune, kind editKind) bool {
+	if !f.confirm(start, end, text, r) {
+		return false
+	}
+	current := f.joinedText()
+	start, end = min(start, len(current)), min(end, len(current))
+	f.record(historyEntry{pos: start, removed: current[start:end], inserted: text, caret: f.caret, anchor: f.anchor, kind: kind})
+	f.replace(start, end, text)
+	return true
+}
+
+// confirm passes an edit to the changed and edit handlers, and returns
+// whether the edit was accepted.
+func (f *InputField) confirm(start int, end int, text string, r rune) bool {
+	if f.changedFunc != nil {
+		f.Unlock()
+		accept := f.changedFunc(r)
+		f.Lock()
+		if !accept {
+			return false
+		}
+	}
+	if f.editFunc != nil {
+		current := f.text()
+		start, end = min(start, len(current)), min(end, len(current))
+		f.Unlock()
+		accept := f.editFunc(current[:start]+text+current[end:], r)
+		f.Lock()
+		if !accept {
+			return false
+		}
+	}
+	return true
 }
 
 func (f *InputField) handleRunes(runes []rune) bool {
 	var redraw bool
 	for _, r := range runes {
-		if f.changedFunc != nil {
-			f.Unlock()
-			accept := f.changedFunc(r)
-			f.Lock()
-			if !accept {
-				continue
-			}
+		f.processIncoming()
+		start, end := f.selection()
+		if f.edit(start, end, string(r), r, editTyping) {
+			redraw = true
 		}
-
-		f.TextField._write([]byte(string(r)))
-		redraw = true
 	}
-
 	return redraw
 }
 
-func (f *InputField) handleKeys(keys []ebiten.Key) bool {
+func (f *InputField) handleKeys(keys []ebiten.Key, mods Modifier) bool {
 	var redraw bool
 	for _, key := range keys {
-		switch key {
-		case ebiten.KeyBackspace:
-			l := len(f.buffer)
-			if l > 0 {
-				if f.changedFunc != nil {
-					f.Unlock()
-					accept := f.changedFunc(0)
-					f.Lock()
-					if !accept {
-						continue
-					}
-				}
-
-				var rewrap bool
-				if len(f.incoming) != 0 {
-					line := string(f.incoming)
-					f.incoming = append(f.incoming, []byte(line[:len(line)-1])...)
-				} else if len(f.buffer[l-1]) == 0 {
-					f.buffer = f.buffer[:l-1]
-					rewrap = true
-				} else {
-					line := string(f.buffer[l-1])
-					f.buffer[l-1] = []byte(line[:len(line)-1])
-					rewrap = true
-				}
-				if rewrap && (f.needWrap == -1 || f.needWrap > l-1) {
-					f.needWrap = l - 1
-				}
-				redraw = true
-				f.modified = true
-				f.redraw = true
+		if f.handleKey(key, mods) {
+			redraw = true
+		}
+	}
+	return redraw
+}
+
+func (f *InputField) handleKey(key ebiten.Key, mods Modifier) (handled bool) {
+	f.processIncoming()
+	text := f.joinedText()
+	caret := min(f.caret, len(text))
+	start, end := f.selection()
+	selected := start != end
+	word := mods&ModifierControl != 0
+	extend := mods&ModifierShift != 0
+	switch key {
+	case ebiten.KeyBackspace:
+		if selected {
+			f.edit(start, end, "", 0, editDeleting)
+		} else if caret > 0 {
+			start := prevBoundary(text, caret)
+			if word {
+				start = prevWord(text, caret)
+			}
+			f.edit(start, caret, "", 0, editDeleting)
+		}
+	case ebiten.KeyDelete:
+		if selected {
+			f.edit(start, end, "", 0, editDeleting)
+		} else if caret < len(text) {
+			end := nextBoundary(text, caret)
+			if word {
+				end = nextWord(text, caret)
 			}
-		case ebiten.KeyEnter, ebiten.KeyKPEnter:
-			if f.selectedFunc != nil {
-				f.Unlock()
-				accept := f.selectedFunc()
-				f.Lock()
-
-				// Clear input buffer.
-				if accept {
-					f.incoming = f.incoming[:0]
-					f.buffer = f.buffer[:0]
-					f.bufferWrapped = f.bufferWrapped[:0]
-					f.lineWidths = f.lineWidths[:0]
-					f.needWrap = 0
-					f.wrapStart = 0
-					f.modified = true
-					f.redraw = true
-					redraw = true
-				}
-			} else if !f.singleLine {
-				// Append newline.
-				f.incoming = append(f.incoming, '\n')
+			f.edit(caret, end, "", 0, editDeleting)
+		}
+	case ebiten.KeyArrowLeft:
+		if selected && !extend {
+			f.setCaret(start)
+		} else if word {
+			f.moveCaret(prevWord(text, caret), extend)
+		} else {
+			f.moveCaret(prevBoundary(text, caret), extend)
+		}
+	case ebiten.KeyArrowRight:
+		if selected && !extend {
+			f.setCaret(end)
+		} else if word {
+			f.moveCaret(nextWord(text, caret), extend)
+		} else {
+			f.moveCaret(nextBoundary(text, caret), extend)
+		}
+	case ebiten.KeyHome:
+		if word {
+			f.moveCaret(0, extend)
+		} else {
+			line, _ := f.lineCol(caret)
+			f.moveCaret(f.textPos(line, 0), extend)
+		}
+	case ebiten.KeyEnd:
+		if word || len(f.buffer) == 0 {
+			f.moveCaret(len(text), extend)
+		} else {
+			line, _ := f.lineCol(caret)
+			f.moveCaret(f.textPos(line, len(f.buffer[line])), extend)
+		}
+	case ebiten.KeyArrowUp, ebiten.KeyArrowDown:
+		if f.singleLine {
+			return false
+		}
+		lines := 1
+		if key == ebiten.KeyArrowUp {
+			lines = -1
+		}
+		f.moveCaret(f.verticalPosition(lines), extend)
+	case ebiten.KeyPageUp, ebiten.KeyPageDown:
+		if f.singleLine {
+			return false
+		}
+		lineHeight := f._lineHeight()
+		lines := max(1, (f.r.Dy()-f.padding*2)/lineHeight-1)
+		if key == ebiten.KeyPageUp {
+			lines = -lines
+		}
+		f.moveCaret(f.verticalPosition(lines), extend)
+		f.offset -= lines * lineHeight
+		f.clampOffset()
+		f.redraw = true
+	case ebiten.KeyTab:
+		if f.singleLine || f.indent == "" || word {
+			return false
+		}
+		startLine, _ := f.lineCol(start)
+		endLine, endCol := f.lineCol(end)
+		if endLine > startLine && endCol == 0 {
+			// The line after the selection is not indented.
+			endLine--
+		}
+		if extend || endLine > startLine {
+			f.indentLines(startLine, endLine, extend)
+		} else {
+			f.edit(start, end, f.indent, '\t', editTyping)
+		}
+	case ebiten.KeyA:
+		if !word {
+			return false
+		}
+		f.setCaret(len(text))
+		f.anchor = 0
+	case ebiten.KeyZ:
+		if !word {
+			return false
+		} else if extend {
+			f.redo()
+		} else {
+			f.undo()
+		}
+	case ebiten.KeyY:
+		if !word {
+			return false
+		}
+		f.redo()
+	case ebiten.KeyEnter, ebiten.KeyKPEnter:
+		if f.selectedFunc != nil {
+			f.Unlock()
+			accept := f.selectedFunc()
+			f.Lock()
+
+			// Clear input buffer.
+			if accept {
+				f.setTextHistory("")
+				f.incoming = f.incoming[:0]
+				f.buffer = f.buffer[:0]
+				f.bufferWrapped = f.bufferWrapped[:0]
+				f.lineWidths = f.lineWidths[:0]
+				f.needWrap = 0
+				f.wrapStart = 0
+				f.caret = 0
+				f.anchor = 0
 				f.modified = true
 				f.redraw = true
-				redraw = true
 			}
+		} else if !f.singleLine {
+			// Insert newline, keeping the indentation of the current line.
+			newline := "\n"
+			if f.indent != "" {
+				line, _ := f.lineCol(start)
+				lineStart := f.textPos(line, 0)
+				indent := text[lineStart:start]
+				newline += indent[:len(indent)-len(strings.TrimLeft(indent, " \t"))]
+			}
+			f.edit(start, end, newline, '\n', editTyping)
 		}
+	default:
+		return false
 	}
-	return redraw
+	return true
+}
+
+// indentLines adds or removes one level of indentation at the start of each
+// line in the text buffer between the provided lines, inclusive. Lines which
+// are not selected are kept in place relative to the caret.
+func (f *InputField) indentLines(first int, last int, outdent bool) {
+	if last >= len(f.buffer) {
+		return
+	}
+	start, end := f.textPos(first, 0), f.textPos(last, len(f.buffer[last]))
+	lines := strings.Split(f.joinedText()[start:end], "\n")
+	var caretShift int
+	caretLine, _ := f.lineCol(f.caret)
+	for i, line := range lines {
+		before := len(line)
+		if !outdent {
+			if line != "" {
+				line = f.indent + line
+			}
+		} else if strings.HasPrefix(line, f.indent) {
+			line = line[len(f.indent):]
+		} else if strings.HasPrefix(line, "\t") {
+			line = line[1:]
+		} else {
+			spaces := len(line) - len(strings.TrimLeft(line, " "))
+			line = line[min(spaces, max(1, len(f.indent))):]
+		}
+		if first+i == caretLine {
+			caretShift = len(line) - before
+		}
+		lines[i] = line
+	}
+	indented := strings.Join(lines, "\n")
+	if indented == f.joinedText()[start:end] {
+		return
+	}
+	caret, anchor := f.caret, f.anchor
+	selected := caret != anchor
+	if !f.edit(start, end, indented, 0, editOther) {
+		return
+	}
+	if selected {
+		// Select the lines which were indented.
+		from, to := start, start+len(indented)
+		if last := max(caret, anchor); last > end {
+			to = last + len(indented) - (end - start)
+		}
+		if caret < anchor {
+			f.setCaret(from)
+			f.anchor = to
+		} else {
+			f.setCaret(to)
+			f.anchor = from
+		}
+		return
+	}
+	f.setCaret(max(f.textPos(caretLine, 0), caret+caretShift))
+}
+
+// verticalPosition returns the position within the text which is displayed
+// the provided number of lines above or below the caret.
+func (f *InputField) verticalPosition(lines int) int {
+	f.fontMutex.Lock()
+	defer f.fontMutex.Unlock()
+
+	f.wrapContent(f.showScrollBar())
+	if len(f.bufferWrapped) == 0 {
+		return f.caret
+	}
+	i, offset := f.wrappedPosition(f.caret)
+	j := i + lines
+	if j < 0 {
+		return 0
+	} else if j >= len(f.bufferWrapped) {
+		return f.textLen()
+	}
+	fromX, _ := f.linePosition(i)
+	toX, _ := f.linePosition(j)
+	x := fromX + f.measure(f.bufferWrapped[i][:offset])
+	return f.textPosition(j, f.offsetAt(j, x-toX))
 }
 
 // Update updates the input field. This function should be called when
@@ -212,11 +598,12 @@ func (f *InputField) Update() error {
 	f.rawRuneBuffer = f.rawRuneBuffer[:0]
 
 	// Handle key input.
+	mods := f.modifiers()
 	f.keyBuffer = inpututil.AppendJustPressedKeys(f.keyBuffer[:0])
-	if f.handleKeys(f.keyBuffer) {
+	if f.handleKeys(f.keyBuffer, mods) {
 		redraw = true
 	}
-	if f.handleKeys(f.rawKeyBuffer) {
+	if f.handleKeys(f.rawKeyBuffer, mods) {
 		redraw = true
 	}
 	f.rawKeyBuffer = f.rawKeyBuffer[:0]
@@ -5,13 +5,16 @@ import (
 	"image"
 	"image/color"
 	"math"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/rivo/uniseg"
 )
 
 // Alignment specifies how text is aligned within the field.
@@ -32,6 +35,15 @@ const (
 	initialPadding     = 5
 	initialScrollWidth = 32
 	maxScroll          = 3
+	scrollLines        = 3
+
+	// doubleClickThreshold is the maximum duration between consecutive clicks
+	// which select a word or line.
+	doubleClickThreshold = 500 * time.Millisecond
+
+	// doubleClickDistance is the maximum distance in pixels between
+	// consecutive clicks which select a word or line.
+	doubleClickDistance = 4
 )
 
 var (
@@ -39,6 +51,8 @@ var (
 	initialBackground   = color.RGBA{255, 255, 255, 255}
 	initialScrollArea   = color.RGBA{200, 200, 200, 255}
 	initialScrollHandle = color.RGBA{108, 108, 108, 255}
+	initialSelection    = color.RGBA{0, 96, 160, 255}
+	initialLineNumber   = color.RGBA{128, 128, 128, 255}
 )
 
 // TextField is a text display field. Call Update and Draw when your Game's
@@ -62,6 +76,65 @@ type TextField struct {
 	// suffix is the text shown after the content of the field.
 	suffix string
 
+	// composition is the text being composed via an input method editor. It
+	// is shown underlined at the caret.
+	composition string
+
+	// editable is whether the text may be edited, in which case the caret is
+	// shown and the caret may be placed by clicking.
+	editable bool
+
+	// caret is the position of the insertion point, in bytes from the start
+	// of the text.
+	caret int
+
+	// caretVisible is whether the caret is drawn while the field is editable
+	// and handles keyboard input.
+	caretVisible bool
+
+	// scrollCaret is whether the field should scroll to the caret the next
+	// time the buffer is modified.
+	scrollCaret bool
+
+	// selectable is whether text may be selected using the mouse while the
+	// field is not editable.
+	selectable bool
+
+	// anchor is the position at which the selection starts, in bytes from the
+	// start of the text. The selection extends from the anchor to the caret.
+	anchor int
+
+	// selecting is whether the selection is being extended by dragging.
+	selecting bool
+
+	// clicks is the number of consecutive clicks within the double-click
+	// threshold.
+	clicks int
+
+	// lastClick is the time of the last click.
+	lastClick time.Time
+
+	// lastClickPoint is the location of the last click.
+	lastClickPoint image.Point
+
+	// selectionColor is the color of the selection highlight.
+	selectionColor color.RGBA
+
+	// caretRect is the location of the insertion point within the field as of
+	// the last time the field was redrawn.
+	caretRect image.Rectangle
+
+	// lineNumbers is whether line numbers are shown in a gutter at the start
+	// of each line. Line numbers are not shown in single line mode.
+	lineNumbers bool
+
+	// lineNumberColor is the color of the line numbers.
+	lineNumberColor color.RGBA
+
+	// gutterWidth is the width of the line number gutter as of the last time
+	// the content of the field was wrapped.
+	gutterWidth int
+
 	// wordWrap determines whether content is wrapped at word boundaries.
 	wordWrap bool
 
@@ -72,6 +145,14 @@ type TextField struct {
 	// to the last line number in the actual text buffer.
 	wrapStart int
 
+	// wrapPositions is the position of each line in bufferWrapped within the
+	// actual text buffer.
+	wrapPositions []wrapPosition
+
+	// joined is whether all lines of the actual text buffer were joined into a
+	// single line when they were wrapped.
+	joined bool
+
 	// needWrap is the first line number in the actual text buffer that needs to be wrapped.
 	needWrap int
 
@@ -204,6 +285,16 @@ type TextField struct {
 	sync.Mutex
 }
 
+// wrapPosition is the position of a wrapped line within the text buffer.
+type wrapPosition struct {
+	// line is the line number in the actual text buffer.
+	line int
+
+	// start is the offset in bytes of the wrapped line within the buffer
+	// line, including the prefix and composition.
+	start int
+}
+
 // NewTextField returns a new TextField. See type documentation for more info.
 func NewTextField(fontSource *text.GoTextFaceSource, fontSize int, fontMutex *sync.Mutex) *TextField {
 	if fontMutex == nil {
@@ -220,6 +311,8 @@ func NewTextField(fontSource *text.GoTextFaceSource, fontSize int, fontMutex *sy
 		scrollWidth:       initialScrollWidth,
 		scrollAreaColor:   initialScrollArea,
 		scrollHandleColor: initialScrollHandle,
+		selectionColor:    initialSelection,
+		lineNumberColor:   initialLineNumber,
 		wordWrap:          true,
 		scrollVisible:     true,
 		scrollAutoHide:    true,
@@ -289,11 +382,264 @@ func (f *TextField) SetText(text string) {
 	f.needWrap = 0
 	f.wrapStart = 0
 	f.incoming = append(f.incoming[:0], []byte(text)...)
+	f.caret = 0
+	f.anchor = 0
+	f.scrollCaret = f.editable
 	f.modified = true
 	f.redraw = true
 	f.resizeFont()
 }
 
+// Caret returns the position of the insertion point, in bytes from the start
+// of the text.
+func (f *TextField) Caret() int {
+	f.Lock()
+	defer f.Unlock()
+
+	f.processIncoming()
+	return min(f.caret, f.textLen())
+}
+
+// SetCaret sets the position of the insertion point, in bytes from the start
+// of the text. The position is moved to the nearest valid position within the
+// text.
+func (f *TextField) SetCaret(pos int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.processIncoming()
+	text := f.joinedText()
+	f.setCaret(clusterStart(text, pos))
+}
+
+// setCaret moves the caret, clears the selection and scrolls the field to
+// show the caret.
+func (f *TextField) setCaret(pos int) {
+	f.caret = max(0, min(pos, f.textLen()))
+	f.anchor = f.caret
+	f.scrollCaret = true
+	f.modified = true
+}
+
+// moveCaret moves the caret, extending the selection when extend is true.
+func (f *TextField) moveCaret(pos int, extend bool) {
+	anchor := f.anchor
+	f.setCaret(pos)
+	if extend {
+		f.anchor = anchor
+	}
+}
+
+// selection returns the bounds of the selection.
+func (f *TextField) selection() (start int, end int) {
+	n := f.textLen()
+	start, end = min(f.anchor, n), min(f.caret, n)
+	if start > end {
+		start, end = end, start
+	}
+	return start, end
+}
+
+// Selection returns the bounds of the selected text, in bytes from the start
+// of the text. When no text is selected, start and end are both the position
+// of the caret.
+func (f *TextField) Selection() (start int, end int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.processIncoming()
+	return f.selection()
+}
+
+// SetSelection selects the text between the provided positions, in bytes from
+// the start of the text, and moves the caret to end. The positions are moved
+// to the nearest valid positions within the text.
+func (f *TextField) SetSelection(start int, end int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.processIncoming()
+	text := f.joinedText()
+	f.setCaret(clusterStart(text, end))
+	f.anchor = clusterStart(text, start)
+}
+
+// SelectedText returns the selected text.
+func (f *TextField) SelectedText() string {
+	f.Lock()
+	defer f.Unlock()
+
+	f.processIncoming()
+	start, end := f.selection()
+	return f.joinedText()[start:end]
+}
+
+// SetSelectable sets whether text may be selected using the mouse. Text may
+// always be selected within editable fields, such as an InputField. Text
+// within selectable fields may not be scrolled by dragging it.
+func (f *TextField) SetSelectable(selectable bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.selectable = selectable
+	if !selectable && !f.editable {
+		f.anchor = f.caret
+		f.selecting = false
+		f.redraw = true
+	}
+}
+
+// SetSelectionColor sets the color of the selection highlight.
+func (f *TextField) SetSelectionColor(c color.RGBA) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.selectionColor = c
+	f.redraw = true
+}
+
+// SetLineNumbers sets whether line numbers are shown in a gutter at the start
+// of each line. Wrapped lines are numbered once. Line numbers are not shown in
+// single line mode.
+func (f *TextField) SetLineNumbers(show bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.lineNumbers == show {
+		return
+	}
+	f.lineNumbers = show
+	f.modified = true
+}
+
+// SetLineNumberColor sets the color of the line numbers.
+func (f *TextField) SetLineNumberColor(c color.RGBA) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.lineNumberColor = c
+	f.redraw = true
+}
+
+// lineNumberWidth returns the width of the line number gutter, which is wide
+// enough to fit the number of the last line.
+func (f *TextField) lineNumberWidth() int {
+	if !f.lineNumbers || f.singleLine {
+		return 0
+	}
+	digits := len(strconv.Itoa(max(1, len(f.buffer))))
+	w, _ := text.Measure(strings.Repeat("0", digits), f.fontFace, float64(f._lineHeight()))
+	return int(w) + f.padding*2
+}
+
+// selectAt selects text at the provided point, which is relative to the
+// field, as the field is clicked. A single click moves the caret, a double
+// click selects a word and a triple click selects a line.
+func (f *TextField) selectAt(p image.Point) {
+	now := time.Now()
+	d := p.Sub(f.lastClickPoint)
+	if f.clicks < 3 && now.Sub(f.lastClick) <= doubleClickThreshold && max(d.X, -d.X, d.Y, -d.Y) <= doubleClickDistance {
+		f.clicks++
+	} else {
+		f.clicks = 1
+	}
+	f.lastClick, f.lastClickPoint = now, p
+
+	f.processIncoming()
+	pos := f.positionAt(p)
+	switch f.clicks {
+	case 1:
+		f.setCaret(pos)
+		f.selecting = true
+	case 2:
+		start, end := wordAt(f.joinedText(), pos)
+		f.setCaret(end)
+		f.anchor = start
+	default:
+		if len(f.buffer) == 0 {
+			f.setCaret(0)
+			return
+		}
+		line, _ := f.lineCol(pos)
+		f.setCaret(f.textPos(line, len(f.buffer[line])))
+		f.anchor = f.textPos(line, 0)
+	}
+}
+
+// joinedText returns the text in the field without processing incoming text.
+func (f *TextField) joinedText() string {
+	return string(bytes.Join(f.buffer, []byte("\n")))
+}
+
+// textLen returns the length of the text in bytes.
+func (f *TextField) textLen() int {
+	n := max(0, len(f.buffer)-1)
+	for _, line := range f.buffer {
+		n += len(line)
+	}
+	return n
+}
+
+// lineCol returns the buffer line and column of a position within the text.
+func (f *TextField) lineCol(pos int) (line int, col int) {
+	for i, l := range f.buffer {
+		if pos <= len(l) || i == len(f.buffer)-1 {
+			return i, max(0, min(pos, len(l)))
+		}
+		pos -= len(l) + 1
+	}
+	return 0, 0
+}
+
+// textPos returns the position within the text of a buffer line and column.
+func (f *TextField) textPos(line int, col int) int {
+	pos := col
+	for i := 0; i < line && i < len(f.buffer); i++ {
+		pos += len(f.buffer[i]) + 1
+	}
+	return pos
+}
+
+// replace replaces the text between the provided positions and moves the
+// caret to the end of the replacement text.
+func (f *TextField) replace(start int, end int, text string) {
+	f.processIncoming()
+	if len(f.buffer) == 0 {
+		f.buffer = append(f.buffer, nil)
+	}
+	startLine, startCol := f.lineCol(start)
+	endLine, endCol := f.lineCol(end)
+
+	parts := strings.Split(text, "\n")
+	lines := make([][]byte, len(parts))
+	for i, part := range parts {
+		lines[i] = []byte(part)
+	}
+	lines[0] = append(append([]byte(nil), f.buffer[startLine][:startCol]...), lines[0]...)
+	last := len(lines) - 1
+	lines[last] = append(lines[last], f.buffer[endLine][endCol:]...)
+	f.buffer = append(f.buffer[:startLine], append(lines, f.buffer[endLine+1:]...)...)
+
+	f.invalidateLine(startLine)
+	f.setCaret(f.textPos(startLine, startCol) + len(text))
+	f.redraw = true
+}
+
+// invalidateLine marks the provided buffer line, and all lines after it, as
+// needing to be wrapped.
+func (f *TextField) invalidateLine(line int) {
+	if f.needWrap != -1 && f.needWrap <= line {
+		return
+	}
+	for i, p := range f.wrapPositions {
+		if p.line == line {
+			f.needWrap, f.wrapStart = line, i
+			return
+		}
+	}
+	f.needWrap, f.wrapStart = 0, 0
+}
+
 // SetLast sets the text of the last line of the field. Newline characters are
 // replaced with spaces.
 func (f *TextField) SetLast(text string) {
@@ -412,6 +758,10 @@ func (f *TextField) LineHeight() int {
 	f.Lock()
 	defer f.Unlock()
 
+	return f._lineHeight()
+}
+
+func (f *TextField) _lineHeight() int {
 	if f.overrideLineHeight != 0 {
 		return f.overrideLineHeight
 	}
@@ -775,26 +1125,28 @@ func (f *TextField) HandleMouseEvent(cursor image.Point, pressed bool, clicked b
 }
 
 func (f *TextField) _handleMouseEvent(cursor image.Point, pressed bool, clicked bool) (handled bool, err error) {
-	if !f.scrollDrag && !cursor.In(f.r) {
+	if !f.scrollDrag && !f.selecting && !cursor.In(f.r) {
 		return false, nil
 	}
 
-	// Handle mouse wheel.
-	_, scroll := ebiten.Wheel()
-	if scroll != 0 {
-		if scroll < -maxScroll {
-			scroll = -maxScroll
-		} else if scroll > maxScroll {
-			scroll = maxScroll
-		}
-		lineHeight := f.overrideLineHeight
-		if lineHeight == 0 {
-			lineHeight = f.lineHeight
+	// Handle placing the caret and selecting text.
+	if f.editable || f.selectable {
+		p := image.Point{cursor.X - f.r.Min.X, cursor.Y - f.r.Min.Y}
+		if clicked && (!f.showScrollBar() || !p.In(f.scrollRect)) {
+			f.fontMutex.Lock()
+			f.selectAt(p)
+			f.fontMutex.Unlock()
+			return true, nil
+		} else if f.selecting {
+			if pressed {
+				f.fontMutex.Lock()
+				f.moveCaret(f.positionAt(p), true)
+				f.fontMutex.Unlock()
+			} else {
+				f.selecting = false
+			}
+			return true, nil
 		}
-		offsetAmount := float64(lineHeight * 3)
-		f.offset += int(scroll * offsetAmount)
-		f.clampOffset()
-		f.redraw = true
 	}
 
 	// Handle scroll bar click (and drag).
@@ -804,7 +1156,7 @@ func (f *TextField) _handleMouseEvent(cursor image.Point, pressed bool, clicked
 		p := image.Point{cursor.X - f.r.Min.X, cursor.Y - f.r.Min.Y}
 		if pressed {
 			// Handle dragging the text field directly.
-			if !f.scrollDrag && !p.In(f.scrollRect) && f.scrollDragPoint.X == -1 && f.scrollDragPoint.Y == -1 {
+			if !f.scrollDrag && !f.editable && !f.selectable && !p.In(f.scrollRect) && f.scrollDragPoint.X == -1 && f.scrollDragPoint.Y == -1 {
 				f.scrollDragPoint = p
 				f.scrollDragOffset = f.offset
 			}
@@ -842,6 +1194,39 @@ func (f *TextField) _handleMouseEvent(cursor image.Point, pressed bool, clicked
 	return true, nil
 }
 
+// HandleScrollEvent scrolls the field by the provided number of lines.
+// Positive values scroll toward the top of the field. Horizontal scrolling
+// is not supported. Handled is false when the field is unable to scroll
+// any further in the provided direction.
+func (f *TextField) HandleScrollEvent(x float64, y float64) (handled bool, err error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if !f.visible || rectIsZero(f.r) {
+		return false, nil
+	}
+
+	return f._handleScrollEvent(x, y)
+}
+
+func (f *TextField) _handleScrollEvent(x float64, y float64) (handled bool, err error) {
+	if y == 0 {
+		return false, nil
+	}
+	lineHeight := f.overrideLineHeight
+	if lineHeight == 0 {
+		lineHeight = f.lineHeight
+	}
+	offset := f.offset
+	f.offset += int(y * float64(lineHeight))
+	f.clampOffset()
+	if f.offset == offset {
+		return false, nil
+	}
+	f.redraw = true
+	return true, nil
+}
+
 // Update updates the field. This function should be called when
 // Game.Update is called.
 func (f *TextField) Update() error {
@@ -874,6 +1259,19 @@ func (f *TextField) Update() error {
 
 	cx, cy := ebiten.CursorPosition()
 	if cx != 0 || cy != 0 {
+		_, scroll := ebiten.Wheel()
+		if scroll != 0 && image.Pt(cx, cy).In(f.r) {
+			if scroll < -maxScroll {
+				scroll = -maxScroll
+			} else if scroll > maxScroll {
+				scroll = maxScroll
+			}
+			_, err := f._handleScrollEvent(0, scroll*scrollLines)
+			if err != nil {
+				return err
+			}
+		}
+
 		handled, err := f._handleMouseEvent(image.Point{X: cx, Y: cy}, ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft), inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft))
 		if err != nil {
 			return err
@@ -928,31 +1326,42 @@ func (f *TextField) fontUpdated() {
 }
 
 func (f *TextField) wrapContent(withScrollBar bool) (wrappedChar bool) {
-	if withScrollBar != f.wrapScrollBar {
+	gutterWidth := f.lineNumberWidth()
+	if withScrollBar != f.wrapScrollBar || gutterWidth != f.gutterWidth {
 		f.needWrap = 0
 		f.wrapStart = 0
 	} else if f.needWrap == -1 {
 		return wrappedChar
 	}
 	f.wrapScrollBar = withScrollBar
+	f.gutterWidth = gutterWidth
 
-	lineHeight := f.overrideLineHeight
-	if lineHeight == 0 {
-		lineHeight = f.lineHeight
-	}
+	lineHeight := f._lineHeight()
+	caretLine, caretCol := f.lineCol(f.caret)
 	if len(f.buffer) == 0 || (f.singleLine && !f.autoResize) {
 		buffer := f.prefix + string(bytes.Join(f.buffer, nil)) + f.suffix
+		if f.composition != "" {
+			offset := f.joinedOffset(caretLine, caretCol)
+			buffer = buffer[:offset] + f.composition + buffer[offset:]
+		}
 		w, _ := text.Measure(buffer, f.fontFace, float64(lineHeight))
 
 		f.bufferWrapped = []string{buffer}
 		f.wrapStart = 0
+		f.wrapPositions = append(f.wrapPositions[:0], wrapPosition{})
+		f.joined = true
 		f.lineWidths = append(f.lineWidths[:0], int(w))
 
 		f.needWrap = -1
 		return wrappedChar
 	}
+	if f.joined {
+		f.needWrap = 0
+		f.wrapStart = 0
+		f.joined = false
+	}
 
-	w := f.r.Dx()
+	w := f.r.Dx() - f.gutterWidth
 	if withScrollBar {
 		w -= f.scrollWidth
 	}
@@ -963,12 +1372,19 @@ func (f *TextField) wrapContent(withScrollBar bool) (wrappedChar bool) {
 	var wordCursor int // Marks the beginning of the word being measured.
 	var charCursor int // Marks the position of the character being measured.
 	var lineWidth int  // Width of the wrapped line segment so far.
+	var bufferLine int // Line number in the actual text buffer.
 	saveWrappedLine := func(wrapped string, width int) {
 		if len(f.bufferWrapped) <= j {
 			f.bufferWrapped = append(f.bufferWrapped, wrapped)
 		} else {
 			f.bufferWrapped[j] = wrapped
 		}
+		position := wrapPosition{line: bufferLine, start: lineCursor}
+		if len(f.wrapPositions) <= j {
+			f.wrapPositions = append(f.wrapPositions, position)
+		} else {
+			f.wrapPositions[j] = position
+		}
 		if len(f.lineWidths) <= j {
 			f.lineWidths = append(f.lineWidths, width)
 		} else {
@@ -985,6 +1401,13 @@ func (f *TextField) wrapContent(withScrollBar bool) (wrappedChar bool) {
 		} else {
 			line = string(f.buffer[i])
 		}
+		if i == caretLine && f.composition != "" {
+			col := caretCol
+			if i == 0 {
+				col += len(f.prefix)
+			}
+			line = line[:col] + f.composition + line[col:]
+		}
 		if i == bufferLen-1 {
 			line += f.suffix
 		}
@@ -992,24 +1415,17 @@ func (f *TextField) wrapContent(withScrollBar bool) (wrappedChar bool) {
 		availableWidth := w - (f.padding * 2)
 
 		f.wrapStart = j
+		bufferLine = i
+		lineCursor = 0
 
-		// BoundString returns 0 for strings containing only whitespace.
+		// Lines containing only whitespace are not wrapped. They are kept
+		// as-is so the caret may be placed within them.
 		if len(strings.TrimSpace(line)) == 0 {
-			if len(f.bufferWrapped) <= j {
-				f.bufferWrapped = append(f.bufferWrapped, "")
-			} else {
-				f.bufferWrapped[j] = ""
-			}
-			if len(f.lineWidths) <= j {
-				f.lineWidths = append(f.lineWidths, 0)
-			} else {
-				f.lineWidths[j] = 0
-			}
-			j++
+			w, _ := text.Measure(line, f.fontFace, float64(lineHeight))
+			saveWrappedLine(line, int(w))
 			continue
 		}
 
-		lineCursor = 0
 	WRAPLINE:
 		for lineCursor < l {
 			wordCursor = lineCursor
@@ -1045,21 +1461,21 @@ func (f *TextField) wrapContent(withScrollBar bool) (wrappedChar bool) {
 					// Break at character of current word.
 					var charWidth int
 					charCursor = wordCursor
-					for _, r := range line[wordCursor:] {
-						runeSize := len(string(r))
-						w, _ := text.Measure(line[wordCursor:charCursor+runeSize], f.fontFace, float64(lineHeight))
+					for charCursor < l {
+						clusterSize := nextBoundary(line, charCursor) - charCursor
+						w, _ := text.Measure(line[wordCursor:charCursor+clusterSize], f.fontFace, float64(lineHeight))
 						boundsWidth := int(w)
 						if lineWidth+boundsWidth > availableWidth {
 							if charWidth == 0 {
 								charWidth = boundsWidth
 							}
 							if lineCursor == charCursor {
-								charCursor += runeSize
+								charCursor += clusterSize
 							}
 							break
 						}
 						charWidth = boundsWidth
-						charCursor += runeSize
+						charCursor += clusterSize
 					}
 					saveWrappedLine(line[lineCursor:charCursor], lineWidth+charWidth)
 					lineCursor = charCursor
@@ -1081,6 +1497,9 @@ func (f *TextField) wrapContent(withScrollBar bool) (wrappedChar bool) {
 	if len(f.bufferWrapped) >= j {
 		f.bufferWrapped = f.bufferWrapped[:j]
 	}
+	if len(f.wrapPositions) >= j {
+		f.wrapPositions = f.wrapPositions[:j]
+	}
 
 	f.needWrap = -1
 	return wrappedChar
@@ -1098,24 +1517,10 @@ func (f *TextField) drawContent() (overflow bool) {
 	if f.showScrollBar() {
 		fieldWidth -= f.scrollWidth
 	}
-	lines := len(f.bufferWrapped)
 
 	h := f.r.Dy()
-	lineHeight := f.overrideLineHeight
-	if lineHeight == 0 {
-		lineHeight = f.lineHeight
-	}
-	var firstVisible, lastVisible int
-	firstVisible = 0
-	lastVisible = len(f.bufferWrapped) - 1
-	if !f.singleLine {
-		firstVisible = (f.offset * -1) / lineHeight
-		lastVisible = firstVisible + (f.r.Dy() / lineHeight) + 1
-		if lastVisible > len(f.bufferWrapped)-1 {
-			lastVisible = len(f.bufferWrapped) - 1
-		}
-	}
-	numVisible := lastVisible - firstVisible
+	lineHeight := f._lineHeight()
+	firstVisible, lastVisible := f.visibleLines()
 	// Calculate buffer size (width for single-line fields or height for multi-line fields).
 	if f.singleLine {
 		w, _ := text.Measure(f.bufferWrapped[firstVisible], f.fontFace, float64(lineHeight))
@@ -1129,15 +1534,23 @@ func (f *TextField) drawContent() (overflow bool) {
 			overflow = true
 		}
 	}
+	selectionStart, selectionEnd := f.selection()
+	var startLine, startOffset, endLine, endOffset int
+	if selectionStart != selectionEnd {
+		startLine, startOffset = f.wrappedPosition(selectionStart)
+		endLine, endOffset = f.wrappedPosition(selectionEnd)
+	} else {
+		startLine, endLine = -1, -1
+	}
 	for i := firstVisible; i <= lastVisible; i++ {
 		line := f.bufferWrapped[i]
 		if f.maskRune != 0 {
-			line = strings.Repeat(string(f.maskRune), len(line))
-			if i == lastVisible && len(line) > 0 && len(line) >= len(f.suffix) {
-				line = line[:len(line)-len(f.suffix)] + f.suffix
+			if i == lastVisible && len(line) >= len(f.suffix) {
+				line = f.displayText(line[:len(line)-len(f.suffix)]) + f.suffix
+			} else {
+				line = f.displayText(line)
 			}
 		}
-		lineX := f.padding
 		lineY := 1 + f.padding + -f.lineOffset + lineHeight*i
 
 		// Calculate whether the line overflows the visible area.
@@ -1151,26 +1564,24 @@ func (f *TextField) drawContent() (overflow bool) {
 			continue
 		}
 
-		// Apply scrolling transformation.
-		if f.singleLine {
-			lineX += f.offset
-		} else {
-			lineY += f.offset
-		}
-
-		// Align horizontally.
-		if f.horizontal == AlignCenter {
-			lineX = (fieldWidth - f.lineWidths[i]) / 2
-		} else if f.horizontal == AlignEnd {
-			lineX = (fieldWidth - f.lineWidths[i]) - f.padding - 1
-		}
+		lineX, lineY := f.linePosition(i)
 
-		// Align vertically.
-		totalHeight := f.lineOffset + lineHeight*(lines)
-		if f.vertical == AlignCenter && (f.autoResize || totalHeight <= h) {
-			lineY = fieldHeight/2 - totalHeight/2 + f.lineOffset + (lineHeight * (i)) - 2
-		} else if f.vertical == AlignEnd && (f.autoResize || totalHeight <= h) {
-			lineY = fieldHeight - lineHeight*(numVisible+1-i) - f.padding
+		// Draw selection.
+		if i >= startLine && i <= endLine {
+			start, end := 0, len(f.bufferWrapped[i])
+			if i == startLine {
+				start = startOffset
+			}
+			if i == endLine {
+				end = endOffset
+			}
+			x1 := lineX + f.measure(f.bufferWrapped[i][:start])
+			x2 := lineX + f.measure(f.bufferWrapped[i][:end])
+			if i != endLine && i+1 < len(f.wrapPositions) && f.wrapPositions[i+1].line != f.wrapPositions[i].line {
+				// Show that the line break is selected.
+				x2 += max(1, lineHeight/4)
+			}
+			f.img.SubImage(image.Rect(x1, lineY, x2, lineY+lineHeight)).(*ebiten.Image).Fill(f.selectionColor)
 		}
 
 		// Draw line.
@@ -1178,11 +1589,235 @@ func (f *TextField) drawContent() (overflow bool) {
 		op.GeoM.Translate(float64(lineX), float64(lineY))
 		op.ColorScale.ScaleWithColor(f.textColor)
 		text.Draw(f.img, line, f.fontFace, op)
+
+		// Draw line number at the start of each line in the text buffer.
+		if f.gutterWidth != 0 && i < len(f.wrapPositions) && f.wrapPositions[i].start == 0 {
+			number := strconv.Itoa(f.wrapPositions[i].line + 1)
+			w, _ := text.Measure(number, f.fontFace, float64(lineHeight))
+			op := &text.DrawOptions{}
+			op.GeoM.Translate(float64(f.gutterWidth-f.padding-int(w)), float64(lineY))
+			op.ColorScale.ScaleWithColor(f.lineNumberColor)
+			text.Draw(f.img, number, f.fontFace, op)
+		}
 	}
 
+	f.drawCaret()
 	return overflow
 }
 
+// visibleLines returns the first and last lines in bufferWrapped which are
+// visible within the field.
+func (f *TextField) visibleLines() (first int, last int) {
+	first, last = 0, len(f.bufferWrapped)-1
+	if !f.singleLine {
+		lineHeight := f._lineHeight()
+		first = (f.offset * -1) / lineHeight
+		last = min(first+(f.r.Dy()/lineHeight)+1, len(f.bufferWrapped)-1)
+	}
+	return first, last
+}
+
+// linePosition returns the location of a line in bufferWrapped within the
+// field, after scrolling and alignment are applied.
+func (f *TextField) linePosition(i int) (x int, y int) {
+	fieldWidth := f.r.Dx()
+	fieldHeight := f.r.Dy()
+	if f.showScrollBar() {
+		fieldWidth -= f.scrollWidth
+	}
+	h := f.r.Dy()
+	lineHeight := f._lineHeight()
+	lines := len(f.bufferWrapped)
+	firstVisible, lastVisible := f.visibleLines()
+	numVisible := lastVisible - firstVisible
+
+	x = f.padding + f.gutterWidth
+	y = 1 + f.padding + -f.lineOffset + lineHeight*i
+
+	// Apply scrolling transformation.
+	if f.singleLine {
+		x += f.offset
+	} else {
+		y += f.offset
+	}
+
+	// Align horizontally.
+	if f.horizontal == AlignCenter {
+		x = f.gutterWidth + (fieldWidth-f.gutterWidth-f.lineWidths[i])/2
+	} else if f.horizontal == AlignEnd {
+		x = (fieldWidth - f.lineWidths[i]) - f.padding - 1
+	}
+
+	// Align vertically.
+	totalHeight := f.lineOffset + lineHeight*(lines)
+	if f.vertical == AlignCenter && (f.autoResize || totalHeight <= h) {
+		y = fieldHeight/2 - totalHeight/2 + f.lineOffset + (lineHeight * (i)) - 2
+	} else if f.vertical == AlignEnd && (f.autoResize || totalHeight <= h) {
+		y = fieldHeight - lineHeight*(numVisible+1-i) - f.padding
+	}
+	return x, y
+}
+
+// displayText returns the provided text as it is displayed, with each
+// grapheme cluster replaced by the mask rune when one is set.
+func (f *TextField) displayText(s string) string {
+	if f.maskRune == 0 {
+		return s
+	}
+	return strings.Repeat(string(f.maskRune), uniseg.GraphemeClusterCount(s))
+}
+
+// measure returns the width of the provided text as it is displayed.
+func (f *TextField) measure(s string) int {
+	w, _ := text.Measure(f.displayText(s), f.fontFace, float64(f._lineHeight()))
+	return int(w)
+}
+
+// joinedOffset returns the offset of a buffer line and column within the
+// text when all lines are joined into a single line.
+func (f *TextField) joinedOffset(line int, col int) int {
+	offset := len(f.prefix) + col
+	for i := 0; i < line && i < len(f.buffer); i++ {
+		offset += len(f.buffer[i])
+	}
+	return offset
+}
+
+// wrappedPosition returns the line in bufferWrapped and the offset within it
+// at which a position within the text is displayed. Positions at the caret
+// are displayed after the composition.
+func (f *TextField) wrappedPosition(pos int) (i int, offset int) {
+	line, col := f.lineCol(pos)
+	caretLine, caretCol := f.lineCol(f.caret)
+	if f.composition != "" && line == caretLine && col >= caretCol {
+		col += len(f.composition)
+	}
+	if f.joined {
+		col = f.joinedOffset(line, col)
+		line = 0
+	} else if line == 0 {
+		col += len(f.prefix)
+	}
+	for i := len(f.wrapPositions) - 1; i >= 0; i-- {
+		p := f.wrapPositions[i]
+		if p.line == line && p.start <= col && i < len(f.bufferWrapped) {
+			return i, min(col-p.start, len(f.bufferWrapped[i]))
+		}
+	}
+	return 0, 0
+}
+
+// textPosition returns the position within the text which is displayed at the
+// provided offset within a line in bufferWrapped.
+func (f *TextField) textPosition(i int, offset int) int {
+	if i < 0 || i >= len(f.wrapPositions) {
+		return 0
+	}
+	p := f.wrapPositions[i]
+	col := p.start + offset
+	if f.joined {
+		col -= len(f.prefix)
+		for line, l := range f.buffer {
+			if col <= len(l) || line == len(f.buffer)-1 {
+				return f.textPos(line, max(0, min(col, len(l))))
+			}
+			col -= len(l)
+		}
+		return 0
+	} else if p.line == 0 {
+		col -= len(f.prefix)
+	}
+	if p.line >= len(f.buffer) {
+		return f.textLen()
+	}
+	return f.textPos(p.line, max(0, min(col, len(f.buffer[p.line]))))
+}
+
+// offsetAt returns the offset within a line in bufferWrapped nearest to the
+// provided distance from the start of the line.
+func (f *TextField) offsetAt(i int, x int) int {
+	line := f.bufferWrapped[i]
+	var offset, width int
+	for offset < len(line) {
+		next := nextBoundary(line, offset)
+		nextWidth := f.measure(line[:next])
+		if x < (width+nextWidth)/2 {
+			break
+		}
+		offset, width = next, nextWidth
+	}
+	return offset
+}
+
+// positionAt returns the position within the text nearest to the provided
+// point, which is relative to the field.
+func (f *TextField) positionAt(p image.Point) int {
+	if len(f.bufferWrapped) == 0 {
+		return 0
+	}
+	lineHeight := f._lineHeight()
+	first, last := f.visibleLines()
+	i := last
+	for j := first; j <= last; j++ {
+		_, y := f.linePosition(j)
+		if p.Y < y+lineHeight {
+			i = j
+			break
+		}
+	}
+	x, _ := f.linePosition(i)
+	return f.textPosition(i, f.offsetAt(i, p.X-x))
+}
+
+// drawCaret records the location of the insertion point, draws the caret and
+// underlines the composition.
+func (f *TextField) drawCaret() {
+	if len(f.bufferWrapped) == 0 {
+		return
+	}
+	lineHeight := f._lineHeight()
+	i, offset := f.wrappedPosition(f.caret)
+	lineX, lineY := f.linePosition(i)
+	line := f.bufferWrapped[i]
+	x := lineX + f.measure(line[:offset])
+	f.caretRect = image.Rect(x, lineY, x+1, lineY+lineHeight)
+	thickness := max(1, lineHeight/16)
+
+	if f.composition != "" && f.maskRune == 0 {
+		start := lineX + f.measure(line[:max(0, offset-len(f.composition))])
+		y := lineY + int(f.fontFace.Metrics().HAscent) + thickness
+		f.img.SubImage(image.Rect(start, y, x, y+thickness)).(*ebiten.Image).Fill(f.textColor)
+	}
+	if f.editable && f.caretVisible && f.handleKeyboard {
+		f.img.SubImage(image.Rect(x, lineY, x+thickness, lineY+lineHeight)).(*ebiten.Image).Fill(f.textColor)
+	}
+}
+
+// scrollToCaret scrolls the field to show the caret.
+func (f *TextField) scrollToCaret() {
+	if len(f.bufferWrapped) == 0 {
+		return
+	}
+	i, offset := f.wrappedPosition(f.caret)
+	if f.singleLine {
+		x := f.measure(f.bufferWrapped[i][:offset])
+		width := f.r.Dx() - f.padding*2 - 1
+		if x+f.offset < 0 {
+			f.offset = -x
+		} else if x+f.offset > width {
+			f.offset = width - x
+		}
+		return
+	}
+	lineHeight := f._lineHeight()
+	y := 1 + f.padding - f.lineOffset + lineHeight*i
+	if y+f.offset < 0 {
+		f.offset = -y
+	} else if y+lineHeight+f.offset > f.r.Dy() {
+		f.offset = f.r.Dy() - lineHeight - y
+	}
+}
+
 func (f *TextField) clampOffset() {
 	fieldSize := f.r.Dy()
 	if f.singleLine {
@@ -1282,6 +1917,18 @@ func (f *TextField) processIncoming() {
 		return
 	}
 
+	n := f.textLen()
+	caretAtEnd, anchorAtEnd := f.caret >= n, f.anchor >= n
+	defer func() {
+		n := f.textLen()
+		if caretAtEnd {
+			f.caret = n
+		}
+		if anchorAtEnd {
+			f.anchor = n
+		}
+	}()
+
 	line := len(f.buffer) - 1
 	if line < 0 {
 		line = 0
@@ -1308,9 +1955,12 @@ func (f *TextField) bufferModified() {
 	f.drawImage()
 
 	lastOffset := f.offset
-	if f.follow {
+	if f.scrollCaret && (f.editable || f.selectable) {
+		f.scrollToCaret()
+	} else if f.follow && !f.editable {
 		f.offset = -math.MaxInt
 	}
+	f.scrollCaret = false
 	f.clampOffset()
 	if f.offset != lastOffset {
 		f.drawImage()
@@ -5,9 +5,11 @@ import (
 	"embed"
 	"fmt"
 	"image"
+	"strings"
 	"sync"
 	"testing"
 
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
@@ -141,3 +143,261 @@ func BenchmarkWrapContent(b *testing.B) {
 		})
 	}
 }
+
+func TestInputFieldCaret(t *testing.T) {
+	f := NewInputField(defaultFont(), 24, &sync.Mutex{})
+	f.SetRect(image.Rect(0, 0, 400, 40))
+	f.SetHandleKeyboard(true)
+	f.SetSingleLine(true)
+	f.SetText("hello world")
+
+	steps := []struct {
+		key   ebiten.Key
+		r     rune
+		mods  Modifier
+		text  string
+		caret int
+	}{
+		{ebiten.KeyHome, 0, 0, "hello world", 0},
+		{ebiten.KeyArrowRight, 0, ModifierControl, "hello world", 5},
+		{-1, ',', 0, "hello, world", 6},
+		{ebiten.KeyArrowLeft, 0, 0, "hello, world", 5},
+		{ebiten.KeyDelete, 0, 0, "hello world", 5},
+		{ebiten.KeyBackspace, 0, ModifierControl, " world", 0},
+		{ebiten.KeyEnd, 0, 0, " world", 6},
+		{ebiten.KeyArrowLeft, 0, ModifierControl, " world", 1},
+		{ebiten.KeyBackspace, 0, 0, "world", 0},
+	}
+	for i, step := range steps {
+		handled, err := f.HandleKeyEvent(step.key, step.r, step.mods)
+		if err != nil {
+			t.Fatal(err)
+		} else if !handled {
+			t.Fatalf("step %d: event not handled", i)
+		}
+		if text, caret := f.Text(), f.Caret(); text != step.text || caret != step.caret {
+			t.Fatalf("step %d: expected %q with caret at %d, got %q with caret at %d", i, step.text, step.caret, text, caret)
+		}
+	}
+
+	handled, err := f.HandleKeyEvent(ebiten.KeyArrowUp, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if handled {
+		t.Fatal("single-line field handled up arrow")
+	}
+}
+
+// graphemeText contains a letter, a letter followed by a combining mark, a
+// family emoji joined by zero-width joiners and a flag.
+const graphemeText = "ae\u0301👩\u200d👩\u200d👧🇯🇵"
+
+func TestGraphemeBoundaries(t *testing.T) {
+	boundaries := []int{0, 1, 4, 22, 30}
+	for i := 1; i < len(boundaries); i++ {
+		if next := nextBoundary(graphemeText, boundaries[i-1]); next != boundaries[i] {
+			t.Fatalf("expected boundary after %d at %d, got %d", boundaries[i-1], boundaries[i], next)
+		}
+		if prev := prevBoundary(graphemeText, boundaries[i]); prev != boundaries[i-1] {
+			t.Fatalf("expected boundary before %d at %d, got %d", boundaries[i], boundaries[i-1], prev)
+		}
+	}
+	if start := clusterStart(graphemeText, 10); start != 4 {
+		t.Fatalf("expected cluster containing 10 to start at 4, got %d", start)
+	}
+	if start := clusterStart("a\r\nb", 2); start != 1 {
+		t.Fatalf("expected cluster containing 2 to start at 1, got %d", start)
+	}
+}
+
+func TestInputFieldGraphemes(t *testing.T) {
+	f := NewInputField(defaultFont(), 24, &sync.Mutex{})
+	f.SetRect(image.Rect(0, 0, 400, 40))
+	f.SetHandleKeyboard(true)
+	f.SetText(graphemeText)
+
+	steps := []struct {
+		key   ebiten.Key
+		text  string
+		caret int
+	}{
+		{ebiten.KeyBackspace, "ae\u0301👩\u200d👩\u200d👧", 22},
+		{ebiten.KeyArrowLeft, "ae\u0301👩\u200d👩\u200d👧", 4},
+		{ebiten.KeyArrowLeft, "ae\u0301👩\u200d👩\u200d👧", 1},
+		{ebiten.KeyDelete, "a👩\u200d👩\u200d👧", 1},
+		{ebiten.KeyArrowRight, "a👩\u200d👩\u200d👧", 19},
+		{ebiten.KeyBackspace, "a", 1},
+	}
+	for i, step := range steps {
+		_, err := f.HandleKeyEvent(step.key, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if text, caret := f.Text(), f.Caret(); text != step.text || caret != step.caret {
+			t.Fatalf("step %d: expected %q with caret at %d, got %q with caret at %d", i, step.text, step.caret, text, caret)
+		}
+	}
+
+	f.SetText(graphemeText)
+	f.SetCaret(10)
+	if caret := f.Caret(); caret != 4 {
+		t.Fatalf("expected caret within a grapheme cluster to move to 4, got %d", caret)
+	}
+
+	f.SetMask('*')
+	if masked := f.displayText(graphemeText); masked != "****" {
+		t.Fatalf("expected each grapheme cluster to be masked, got %q", masked)
+	}
+}
+
+func TestInputFieldHistory(t *testing.T) {
+	f := NewInputField(defaultFont(), 24, &sync.Mutex{})
+	f.SetRect(image.Rect(0, 0, 400, 40))
+	f.SetHandleKeyboard(true)
+
+	typeText := func(s string) {
+		for _, r := range s {
+			_, err := f.HandleKeyEvent(-1, r, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	steps := []struct {
+		key  ebiten.Key
+		mods Modifier
+		text string
+	}{
+		{ebiten.KeyZ, ModifierControl, "hi "},
+		{ebiten.KeyZ, ModifierControl, ""},
+		{ebiten.KeyY, ModifierControl, "hi "},
+		{ebiten.KeyZ, ModifierControl | ModifierShift, "hi there"},
+		{ebiten.KeyBackspace, 0, "hi ther"},
+		{ebiten.KeyBackspace, 0, "hi the"},
+		{ebiten.KeyBackspace, 0, "hi th"},
+		{ebiten.KeyZ, ModifierControl, "hi there"},
+		{ebiten.KeyZ, ModifierControl, "hi "},
+	}
+	typeText("hi there")
+	for i, step := range steps {
+		_, err := f.HandleKeyEvent(step.key, 0, step.mods)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if text := f.Text(); text != step.text {
+			t.Fatalf("step %d: expected %q, got %q", i, step.text, text)
+		}
+	}
+
+	typeText("you")
+	if f.Redo() {
+		t.Fatal("expected redo history to be discarded after editing")
+	} else if !f.Undo() || f.Text() != "hi " {
+		t.Fatalf("expected undo to restore %q, got %q", "hi ", f.Text())
+	}
+
+	f.SetText("")
+	if f.Undo() {
+		t.Fatal("expected history to be cleared when setting text")
+	}
+
+	f.SetHistorySize(1)
+	typeText("a b")
+	if !f.Undo() || f.Text() != "a " {
+		t.Fatalf("expected undo to restore %q, got %q", "a ", f.Text())
+	} else if f.Undo() {
+		t.Fatal("expected history to be limited to one edit")
+	}
+
+	f.SetClearHistoryOnSetText(false)
+	f.SetText("replaced")
+	if !f.Undo() || f.Text() != "a " {
+		t.Fatalf("expected undo to restore text replaced by SetText, got %q", f.Text())
+	}
+}
+
+func TestInputFieldIndent(t *testing.T) {
+	f := NewInputField(defaultFont(), 24, &sync.Mutex{})
+	f.SetRect(image.Rect(0, 0, 400, 200))
+	f.SetHandleKeyboard(true)
+	f.SetIndent("  ")
+	f.SetText("if x {")
+
+	steps := []struct {
+		key   ebiten.Key
+		r     rune
+		mods  Modifier
+		text  string
+		caret int
+	}{
+		{ebiten.KeyEnter, 0, 0, "if x {\n", 7},
+		{ebiten.KeyTab, 0, 0, "if x {\n  ", 9},
+		{-1, 'y', 0, "if x {\n  y", 10},
+		{ebiten.KeyEnter, 0, 0, "if x {\n  y\n  ", 13},
+		{ebiten.KeyTab, 0, ModifierShift, "if x {\n  y\n", 11},
+		{ebiten.KeyA, 0, ModifierControl, "if x {\n  y\n", 11},
+		{ebiten.KeyTab, 0, 0, "  if x {\n    y\n", 15},
+		{ebiten.KeyTab, 0, ModifierShift, "if x {\n  y\n", 11},
+	}
+	for i, step := range steps {
+		_, err := f.HandleKeyEvent(step.key, step.r, step.mods)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if text, caret := f.Text(), f.Caret(); text != step.text || caret != step.caret {
+			t.Fatalf("step %d: expected %q with caret at %d, got %q with caret at %d", i, step.text, step.caret, text, caret)
+		}
+	}
+	if start, end := f.Selection(); start != 0 || end != 11 {
+		t.Fatalf("expected indented lines to remain selected, got %d-%d", start, end)
+	}
+
+	f.SetIndent("")
+	if handled, _ := f.HandleKeyEvent(ebiten.KeyTab, 0, 0); handled {
+		t.Fatal("expected tab key to be unhandled without an indent")
+	}
+}
+
+func TestInputFieldPage(t *testing.T) {
+	f := NewInputField(defaultFont(), 24, &sync.Mutex{})
+	f.SetRect(image.Rect(0, 0, 400, 200))
+	f.SetHandleKeyboard(true)
+	f.SetText(strings.Repeat("line\n", 50))
+
+	_, err := f.HandleKeyEvent(ebiten.KeyHome, 0, ModifierControl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.HandleKeyEvent(ebiten.KeyPageDown, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	down := f.Caret()
+	if down == 0 || down%len("line\n") != 0 {
+		t.Fatalf("expected page down to move the caret to the start of a later line, got %d", down)
+	}
+	_, err = f.HandleKeyEvent(ebiten.KeyPageUp, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if caret := f.Caret(); caret != 0 {
+		t.Fatalf("expected page up to return the caret to 0, got %d", caret)
+	}
+}
+
+func TestInputFieldModifierFunc(t *testing.T) {
+	f := NewInputField(defaultFont(), 24, &sync.Mutex{})
+	f.SetRect(image.Rect(0, 0, 400, 200))
+	f.SetHandleKeyboard(true)
+	f.SetText("one two")
+	f.SetCaret(len("one two"))
+	f.SetModifierFunc(func() Modifier {
+		return ModifierControl
+	})
+
+	_, err := f.HandleKeyboardEvent(ebiten.KeyArrowLeft, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if caret := f.Caret(); caret != len("one ") {
+		t.Fatalf("expected ctrl+left to move the caret to the previous word at %d, got %d", len("one "), caret)
+	}
+}
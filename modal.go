@@ -0,0 +1,153 @@
+package etk
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type modal struct {
+	w         Widget
+	lastFocus Widget
+	backdrop  bool
+}
+
+// Modal returns the topmost modal widget of the default UI. See UI.Modal.
+func Modal() Widget {
+	return activeUI().Modal()
+}
+
+// Modal returns the topmost modal widget. If no modal widget is shown, nil is
+// returned.
+func (u *UI) Modal() Widget {
+	if len(u.modals) == 0 {
+		return nil
+	}
+	return u.modals[len(u.modals)-1].w
+}
+
+// PushModal shows a modal widget within the default UI. See UI.PushModal.
+func PushModal(w Widget) {
+	activeUI().PushModal(w)
+}
+
+// PushModal shows a modal widget above the root widget and any other modal
+// widgets. The modal widget is sized to fill the screen, and all user input is
+// passed only to the topmost modal widget. Focus is moved to the modal widget
+// and kept within it until it is removed via PopModal. Widgets beneath the
+// modal widget are dimmed using Style.ModalBackdropColor.
+func (u *UI) PushModal(w Widget) {
+	u.pushModal(w, true)
+}
+
+// pushModal shows a modal widget, optionally dimming the widgets beneath it.
+func (u *UI) pushModal(w Widget, backdrop bool) {
+	if w == nil {
+		return
+	}
+	u.invalidate()
+	for i, m := range u.closingModals {
+		if m.w == w {
+			u.closingModals = append(u.closingModals[:i], u.closingModals[i+1:]...)
+			break
+		}
+	}
+	u.modals = append(u.modals, &modal{
+		w:         w,
+		lastFocus: u.focusedWidget,
+		backdrop:  backdrop,
+	})
+	u.transition(w, Style.ModalTransition, true)
+	if u.lastWidth != 0 || u.lastHeight != 0 {
+		w.SetRect(image.Rect(0, 0, u.lastWidth, u.lastHeight))
+	}
+
+	u.SetFocus(w)
+	if u.focusedWidget == nil || !shown(w, u.focusedWidget) {
+		if u.focusedWidget != nil {
+			u.focusedWidget.SetFocus(false)
+			u.focusedWidget = nil
+		}
+		u.FocusNext()
+	}
+}
+
+// PopModal removes the topmost modal widget of the default UI and returns it.
+// See UI.PopModal.
+func PopModal() Widget {
+	return activeUI().PopModal()
+}
+
+// PopModal removes the topmost modal widget and returns it. Focus is restored
+// to the widget which was focused when the modal widget was shown. If no modal
+// widget is shown, nil is returned.
+func (u *UI) PopModal() Widget {
+	if len(u.modals) == 0 {
+		return nil
+	}
+	m := u.modals[len(u.modals)-1]
+	u.modals = u.modals[:len(u.modals)-1]
+	u.closingModals = append(u.closingModals, m)
+	u.transition(m.w, Style.ModalTransition, false)
+	u.invalidate()
+
+	if u.focusedWidget != nil {
+		u.focusedWidget.SetFocus(false)
+		u.focusedWidget = nil
+	}
+	u.SetFocus(m.lastFocus)
+	return m.w
+}
+
+// activeRoot returns the widget which currently receives user input. This is
+// the topmost modal widget, or the root widget when no modal widget is shown.
+func (u *UI) activeRoot() Widget {
+	if len(u.modals) != 0 {
+		return u.modals[len(u.modals)-1].w
+	}
+	return u.root
+}
+
+// drawModals draws all modal widgets above the root widget.
+// Modal widgets which were removed are drawn above the others until their
+// transitions complete.
+func (u *UI) drawModals(screen *ebiten.Image) error {
+	var closing int
+	for _, m := range u.closingModals {
+		fx := u.effects[m.w]
+		if fx != nil && fx.leaving {
+			u.closingModals[closing] = m
+			closing++
+		}
+	}
+	u.closingModals = u.closingModals[:closing]
+
+	for _, modals := range [2][]*modal{u.modals, u.closingModals} {
+		for _, m := range modals {
+			if m.backdrop && Style.ModalBackdropColor.A > 0 {
+				r := screen.Bounds()
+				c := scaleAlpha(Style.ModalBackdropColor, u.Alpha(m.w))
+				vector.FillRect(screen, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), c, false)
+			}
+			err := u.draw(m.w, screen)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// scaleAlpha returns the provided color with its opacity multiplied by alpha.
+func scaleAlpha(c color.RGBA, alpha float64) color.RGBA {
+	if alpha >= 1 {
+		return c
+	}
+	scale := func(v uint8) uint8 {
+		return uint8(math.Round(float64(v) * alpha))
+	}
+	return color.RGBA{scale(c.R), scale(c.G), scale(c.B), scale(c.A)}
+}
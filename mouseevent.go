@@ -0,0 +1,168 @@
+package etk
+
+import (
+	"image"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MouseEvent represents an extended mouse or touch event.
+type MouseEvent struct {
+	// Cursor is the location of the mouse cursor or touch.
+	Cursor image.Point
+
+	// Button is the mouse button which is held down, was just pressed or was
+	// just released. Touch input is reported as MouseButtonLeft. When no
+	// button is involved, such as when the mouse cursor is moved, Button is -1.
+	Button ebiten.MouseButton
+
+	// Pressed is true while Button is held down.
+	Pressed bool
+
+	// Clicked is true when Button was just pressed.
+	Clicked bool
+
+	// Touch is true when the event was generated by touch input.
+	Touch bool
+
+	// LongPress is true once per touch, when the touch has been held for
+	// Bindings.LongPressThreshold without moving more than
+	// Bindings.DragThreshold pixels. Long-presses are reported to the widget
+	// which was touched.
+	LongPress bool
+
+	// Modifier key states at the time of the event.
+	Ctrl  bool
+	Shift bool
+	Alt   bool
+	Meta  bool
+}
+
+// MouseEventHandler may be implemented by widgets to receive extended mouse
+// events. Widgets which implement MouseEventHandler receive mouse and touch
+// events via HandleMouseEvent instead of HandleMouse, including presses of
+// mouse buttons other than those in Bindings.ConfirmMouse. Widgets which do not
+// implement MouseEventHandler only receive presses of Bindings.ConfirmMouse
+// buttons and touch input via HandleMouse.
+type MouseEventHandler interface {
+	// HandleMouseEvent is called when a mouse event occurs. Only mouse events
+	// that are on top of the widget, or which occur while the widget is
+	// pressed, are passed to the widget.
+	HandleMouseEvent(e MouseEvent) (handled bool, err error)
+}
+
+// mouseButtons are the mouse buttons reported to widgets in addition to
+// Bindings.ConfirmMouse.
+var mouseButtons = []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle}
+
+// newMouseEvent returns a mouse event for the current state of the mouse. A
+// button which was just pressed takes priority over the button which was
+// previously held down.
+func (u *UI) newMouseEvent(cursor image.Point) MouseEvent {
+	e := MouseEvent{
+		Cursor: cursor,
+		Button: -1,
+		Ctrl:   u.input.IsKeyPressed(ebiten.KeyControl),
+		Shift:  u.input.IsKeyPressed(ebiten.KeyShift),
+		Alt:    u.input.IsKeyPressed(ebiten.KeyAlt),
+		Meta:   u.input.IsKeyPressed(ebiten.KeyMeta),
+	}
+	for _, buttons := range [2][]ebiten.MouseButton{Bindings.ConfirmMouse, mouseButtons} {
+		for _, button := range buttons {
+			if u.input.IsMouseButtonJustPressed(button) {
+				u.heldButton = button
+				e.Button, e.Pressed, e.Clicked = button, true, true
+				return e
+			}
+		}
+	}
+	if u.heldButton != -1 {
+		e.Button = u.heldButton
+		e.Pressed = u.input.IsMouseButtonPressed(u.heldButton)
+		if !e.Pressed {
+			u.heldButton = -1
+		}
+	}
+	return e
+}
+
+// newTouchEvent returns a mouse event for the active touch.
+func (u *UI) newTouchEvent(cursor image.Point, pressed bool, clicked bool) MouseEvent {
+	e := u.newMouseEvent(cursor)
+	e.Button, e.Pressed, e.Clicked, e.Touch = ebiten.MouseButtonLeft, pressed, clicked, true
+
+	lp := &u.longPress
+	if clicked {
+		lp.start, lp.origin, lp.done = time.Now(), cursor, false
+	} else if pressed && !lp.done {
+		threshold := Scale(Bindings.DragThreshold)
+		delta := cursor.Sub(lp.origin)
+		if delta.X >= threshold || delta.X <= -threshold || delta.Y >= threshold || delta.Y <= -threshold {
+			lp.done = true
+		} else if time.Since(lp.start) >= Bindings.LongPressThreshold {
+			lp.done = true
+			e.LongPress = true
+		}
+	}
+	return e
+}
+
+type longPressState struct {
+	start  time.Time
+	origin image.Point
+	done   bool
+}
+
+// confirm returns whether the event was generated by touch input or involves
+// a mouse button in Bindings.ConfirmMouse.
+func (e MouseEvent) confirm() bool {
+	if e.Touch {
+		return true
+	}
+	for _, button := range Bindings.ConfirmMouse {
+		if e.Button == button {
+			return true
+		}
+	}
+	return false
+}
+
+// findMouseEventHandler returns the provided widget, or the widget wrapped by
+// it, as a MouseEventHandler. Widgets wrapped via WithoutMouse and
+// WithoutMouseExceptScroll are not returned.
+func findMouseEventHandler(w Widget) MouseEventHandler {
+	for w != nil {
+		handler, ok := w.(MouseEventHandler)
+		if ok {
+			return handler
+		}
+		switch w.(type) {
+		case *WithoutMouse, *WithoutMouseExceptScroll:
+			return nil
+		}
+		w = unwrap(w)
+	}
+	return nil
+}
+
+// mouseEventFor returns the event as received by the provided widget. Presses
+// of mouse buttons other than Bindings.ConfirmMouse are only received by
+// widgets which implement MouseEventHandler.
+func mouseEventFor(w Widget, e MouseEvent) MouseEvent {
+	if e.confirm() || findMouseEventHandler(w) != nil {
+		return e
+	}
+	e.Pressed, e.Clicked, e.LongPress = false, false, false
+	return e
+}
+
+// handleMouseEvent passes a mouse event to a widget.
+func handleMouseEvent(w Widget, e MouseEvent) (handled bool, err error) {
+	handler := findMouseEventHandler(w)
+	if handler != nil {
+		return handler.HandleMouseEvent(e)
+	}
+	e = mouseEventFor(w, e)
+	return w.HandleMouse(e.Cursor, e.Pressed, e.Clicked)
+}
@@ -7,25 +7,61 @@ import (
 	"syscall/js"
 )
 
-func clipboardBuffer() []byte {
+// systemClipboard provides access to the browser clipboard via the
+// getClipboard and setClipboard functions, when defined by the page hosting
+// the application. When the page does not define setClipboard, text is written
+// via navigator.clipboard. Text is also stored in memory, which is read when
+// the page does not define getClipboard.
+type systemClipboard struct {
+	memory MemoryClipboard
+}
+
+func newSystemClipboard() Clipboard {
+	return &systemClipboard{}
+}
+
+// ReadText returns the text on the clipboard.
+func (c *systemClipboard) ReadText() ([]byte, error) {
 	global := js.Global()
 	if !global.Get("getClipboard").Truthy() {
-		return nil
+		return c.memory.ReadText()
 	}
 	promise := global.Call("getClipboard", nil)
 	if !promise.Truthy() {
-		return nil
+		return nil, nil
 	}
 	result := make(chan []byte, 1)
-	promise.Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+	then := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		if len(args) == 0 || !args[0].Truthy() {
 			result <- nil
 			return nil
 		}
 		result <- []byte(args[0].String())
 		return nil
-	}))
-	return <-result
+	})
+	defer then.Release()
+	promise.Call("then", then)
+	return <-result, nil
+}
+
+// WriteText replaces the text on the clipboard.
+func (c *systemClipboard) WriteText(text []byte) error {
+	err := c.memory.WriteText(text)
+	if err != nil {
+		return err
+	}
+
+	global := js.Global()
+	if global.Get("setClipboard").Truthy() {
+		global.Call("setClipboard", string(text))
+		return nil
+	}
+	navigatorClipboard := global.Get("navigator").Get("clipboard")
+	if !navigatorClipboard.Truthy() || !navigatorClipboard.Get("writeText").Truthy() {
+		return nil
+	}
+	navigatorClipboard.Call("writeText", string(text))
+	return nil
 }
 
 // Open opens a file, directory or URI using the default application registered
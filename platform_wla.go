@@ -2,8 +2,37 @@
 
 package etk
 
-import "golang.design/x/clipboard"
+import (
+	"sync"
 
-func clipboardBuffer() []byte {
-	return clipboard.Read(clipboard.FmtText)
+	"golang.design/x/clipboard"
+)
+
+var initClipboard = sync.OnceValue(clipboard.Init)
+
+// systemClipboard provides access to the system clipboard. When the system
+// clipboard is not available, text is stored in memory instead.
+type systemClipboard struct {
+	memory MemoryClipboard
+}
+
+func newSystemClipboard() Clipboard {
+	return &systemClipboard{}
+}
+
+// ReadText returns the text on the clipboard.
+func (c *systemClipboard) ReadText() ([]byte, error) {
+	if initClipboard() != nil {
+		return c.memory.ReadText()
+	}
+	return clipboard.Read(clipboard.FmtText), nil
+}
+
+// WriteText replaces the text on the clipboard.
+func (c *systemClipboard) WriteText(text []byte) error {
+	if initClipboard() != nil {
+		return c.memory.WriteText(text)
+	}
+	clipboard.Write(clipboard.FmtText, text)
+	return nil
 }
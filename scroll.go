@@ -0,0 +1,80 @@
+package etk
+
+import (
+	"image"
+)
+
+// Scroller may be implemented by widgets which scroll their content using the
+// mouse wheel or touchpad.
+type Scroller interface {
+	// HandleScroll is called when the mouse wheel is scrolled over the widget.
+	// The horizontal and vertical deltas are provided in lines, with
+	// Bindings.ScrollSpeed applied. Positive values scroll toward the left
+	// and top of the content. Widgets return a handled value of false when
+	// they are unable to scroll any further in the provided direction, which
+	// passes the event to the nearest scrollable parent widget.
+	HandleScroll(x float64, y float64) (handled bool, err error)
+}
+
+// findScroller returns the provided widget, or the widget wrapped by it, as a
+// Scroller. Widgets wrapped via WithoutMouse are not returned.
+func findScroller(w Widget) Scroller {
+	for w != nil {
+		scroller, ok := w.(Scroller)
+		if ok {
+			return scroller
+		}
+		_, ok = w.(*WithoutMouse)
+		if ok {
+			return nil
+		}
+		w = unwrap(w)
+	}
+	return nil
+}
+
+// handleScroll reads the mouse wheel and passes the deltas to the topmost
+// scrollable widget under the cursor.
+func (u *UI) handleScroll(cursor image.Point) (handled bool, err error) {
+	x, y := u.input.Wheel()
+	if x == 0 && y == 0 {
+		return false, nil
+	}
+	clamp := func(v float64) float64 {
+		if Bindings.MaxScroll <= 0 {
+			return v * Bindings.ScrollSpeed
+		}
+		return max(-Bindings.MaxScroll, min(v, Bindings.MaxScroll)) * Bindings.ScrollSpeed
+	}
+	_, handled, err = u.scroll(u.activeRoot(), cursor, clamp(x), clamp(y))
+	return handled, err
+}
+
+// scroll passes scroll deltas to the topmost widget under the cursor, and then
+// to each of its parent widgets, until the deltas are handled.
+func (u *UI) scroll(w Widget, cursor image.Point, x float64, y float64) (found bool, handled bool, err error) {
+	if w == nil || !w.Visible() {
+		return false, false, nil
+	}
+	children := w.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		found, handled, err = u.scroll(children[i], cursor, x, y)
+		if err != nil || handled {
+			return true, handled, err
+		} else if found {
+			break
+		}
+	}
+	if !found && !cursor.In(w.Rect()) {
+		return false, false, nil
+	}
+	scroller := findScroller(w)
+	if scroller == nil {
+		return true, false, nil
+	}
+	handled, err = scroller.HandleScroll(x, y)
+	if handled {
+		MarkDirty(w)
+	}
+	return true, handled, err
+}
@@ -0,0 +1,45 @@
+package etk_test
+
+import (
+	"testing"
+
+	"codeberg.org/tslocum/etk"
+)
+
+type scrollWidget struct {
+	*testWidget
+	limit bool
+	x, y  float64
+}
+
+func (w *scrollWidget) HandleScroll(x float64, y float64) (handled bool, err error) {
+	if w.limit {
+		return false, nil
+	}
+	w.x, w.y = w.x+x, w.y+y
+	return true, nil
+}
+
+func TestScrollBubbling(t *testing.T) {
+	outer := &scrollWidget{testWidget: newTestWidget()}
+	inner := &scrollWidget{testWidget: newTestWidget(), limit: true}
+	outer.AddChild(inner)
+	u, f, _, _ := setupFakeInput(t, outer)
+
+	f.MoveCursor(50, 50)
+	f.ScrollWheel(1, -1)
+	frame(t, u, f)
+	expected := etk.Bindings.ScrollSpeed
+	if outer.x != expected || outer.y != -expected {
+		t.Fatalf("unhandled scroll did not bubble to parent: expected %v and %v, got %v and %v", expected, -expected, outer.x, outer.y)
+	} else if !u.Consumed().Mouse {
+		t.Fatalf("handled scroll was not consumed")
+	}
+
+	inner.limit = false
+	f.ScrollWheel(0, 1)
+	frame(t, u, f)
+	if inner.y != expected || outer.y != -expected {
+		t.Fatalf("scroll was not handled by topmost widget")
+	}
+}
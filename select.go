@@ -15,6 +15,11 @@ type Select struct {
 	onSelect func(index int) (accept bool)
 	items    []string
 	open     bool
+	focus    bool
+	hover    bool
+	hoverBg  color.RGBA
+
+	transition Transition
 }
 
 // NewSelect returns a new Select widget.
@@ -27,6 +32,9 @@ func NewSelect(itemHeight int, onSelect func(index int) (accept bool)) *Select {
 		Box:      NewBox(),
 		label:    NewText(""),
 		onSelect: onSelect,
+		hoverBg:  Style.SelectBgColorHover,
+
+		transition: Style.SelectTransition,
 	}
 	s.label.SetAutoResize(true)
 	s.label.SetVertical(AlignCenter)
@@ -37,6 +45,7 @@ func NewSelect(itemHeight int, onSelect func(index int) (accept bool)) *Select {
 	s.list.SetDrawBorder(true)
 	s.list.SetVisible(false)
 	s.list.SetSelectionMode(SelectRow)
+	SetTabIndex(s.list, -1)
 	s.AddChild(s.list)
 	s.updateLabel()
 	return s
@@ -70,6 +79,34 @@ func (s *Select) SetHighlightColor(c color.RGBA) {
 	s.list.SetHighlightColor(c)
 }
 
+// SetHoverColor sets the background color of the widget while the mouse
+// cursor hovers over it, and the color used to highlight the item under the
+// mouse cursor in the dropdown menu. A transparent color disables hover
+// highlighting.
+func (s *Select) SetHoverColor(c color.RGBA) {
+	s.Lock()
+	s.hoverBg = c
+	s.Unlock()
+	s.list.SetHoverColor(c)
+	MarkDirty(s)
+}
+
+// MouseEnter is called when the mouse cursor enters the widget.
+func (s *Select) MouseEnter() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.hover = true
+}
+
+// MouseLeave is called when the mouse cursor leaves the widget.
+func (s *Select) MouseLeave() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.hover = false
+}
+
 // SetSelectedItem sets the currently selected item.
 func (s *Select) SetSelectedItem(index int) {
 	s.Lock()
@@ -145,8 +182,7 @@ func (s *Select) updateLabel() {
 
 func (s *Select) selectList(index int) (accept bool) {
 	s.Lock()
-	s.list.grid.visible = false
-	s.open = false
+	s._setMenuVisible(false)
 	onSelect := s.onSelect
 	s.Unlock()
 
@@ -169,9 +205,22 @@ func (s *Select) SetMenuVisible(visible bool) {
 	s._setMenuVisible(visible)
 }
 
+// SetTransition sets the transition shown when the dropdown menu is opened
+// and closed.
+func (s *Select) SetTransition(t Transition) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.transition = t
+}
+
 func (s *Select) _setMenuVisible(visible bool) {
+	wasOpen := s.open
 	s.open = visible
 	s.list.SetVisible(visible)
+	if visible != wasOpen {
+		activeUI().transition(s.list, s.transition, visible)
+	}
 	s.updateLabel()
 
 	if !visible {
@@ -188,9 +237,73 @@ func (s *Select) Cursor() ebiten.CursorShapeType {
 	return ebiten.CursorShapePointer
 }
 
-// HandleKeyboard is called when a keyboard event occurs.
-func (s *Select) HandleKeyboard(ebiten.Key, rune) (handled bool, err error) {
-	return false, nil
+// Focus returns the focus state of the widget.
+func (s *Select) Focus() bool {
+	return s.focus
+}
+
+// focusOnClick returns false, as the widget is only focused via focus
+// traversal. Clicking the widget leaves focus unchanged.
+func (s *Select) focusOnClick() bool {
+	return false
+}
+
+// SetFocus sets the focus state of the widget.
+func (s *Select) SetFocus(focus bool) (accept bool) {
+	s.focus = focus
+	return true
+}
+
+// Accessibility returns the role, name, value and state of the widget.
+func (s *Select) Accessibility() Accessibility {
+	s.Lock()
+	defer s.Unlock()
+
+	var value string
+	selected := s.list.selectedY
+	if selected >= 0 && selected < len(s.items) {
+		value = s.items[selected]
+	}
+	return Accessibility{
+		Role:    RoleComboBox,
+		Value:   value,
+		Focused: s.focus,
+	}
+}
+
+// HandleKeyboard is called when a keyboard event occurs. The dropdown menu is
+// shown when a confirm key is pressed. While the menu is visible, the movement
+// keys change the highlighted option and a confirm key selects it.
+func (s *Select) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
+	if r != 0 {
+		return false, nil
+	}
+
+	var confirm bool
+	for _, confirmKey := range Bindings.ConfirmKeyboard {
+		if key == confirmKey {
+			confirm = true
+			break
+		}
+	}
+
+	s.Lock()
+	if !s.open {
+		if confirm {
+			s._setMenuVisible(true)
+		}
+		s.Unlock()
+		return confirm, nil
+	}
+	s.Unlock()
+
+	if confirm {
+		_, index := s.list.SelectedItem()
+		s.selectList(index)
+		return true, nil
+	}
+	_, err = s.list.HandleKeyboard(key, r)
+	return true, err
 }
 
 // HandleMouse is called when a mouse event occurs.
@@ -209,7 +322,11 @@ func (s *Select) Draw(screen *ebiten.Image) error {
 	s.Lock()
 	defer s.Unlock()
 
-	screen.SubImage(s.label.rect).(*ebiten.Image).Fill(s.background)
+	background := s.background
+	if s.hover && s.hoverBg.A != 0 {
+		background = s.hoverBg
+	}
+	screen.SubImage(s.label.rect).(*ebiten.Image).Fill(background)
 
 	// Draw label.
 	s.label.Draw(screen)
@@ -219,6 +336,9 @@ func (s *Select) Draw(screen *ebiten.Image) error {
 	borderSize := Scale(Style.ButtonBorderSize)
 	borderLeft, borderTop := Style.ButtonBorderLeft, Style.ButtonBorderTop
 	borderRight, borderBottom := Style.ButtonBorderRight, Style.ButtonBorderBottom
+	if s.focus {
+		borderLeft, borderTop, borderRight, borderBottom = Style.ButtonBorderFocused, Style.ButtonBorderFocused, Style.ButtonBorderFocused, Style.ButtonBorderFocused
+	}
 	if !s.open {
 		screen.SubImage(image.Rect(r.Min.X, r.Min.Y, r.Min.X+borderSize, r.Max.Y)).(*ebiten.Image).Fill(borderLeft)
 		screen.SubImage(image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+borderSize)).(*ebiten.Image).Fill(borderTop)
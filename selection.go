@@ -0,0 +1,70 @@
+package etk
+
+// TextSelector may be implemented by widgets which display text that may be
+// selected. Text and Input implement TextSelector. The most recently clicked
+// TextSelector owns the selection, and its selected text is copied when Ctrl+C
+// is pressed, even when it is not focused.
+type TextSelector interface {
+	// Selection returns the bounds of the selected text, in bytes from the
+	// start of the text. When no text is selected, start and end are equal.
+	Selection() (start int, end int)
+
+	// SetSelection selects the text between the provided positions, in bytes
+	// from the start of the text.
+	SetSelection(start int, end int)
+}
+
+// findTextSelector returns the provided widget, or the widget wrapped by it, as
+// a TextSelector.
+func findTextSelector(w Widget) TextSelector {
+	for w != nil {
+		selector, ok := w.(TextSelector)
+		if ok {
+			return selector
+		}
+		w = unwrap(w)
+	}
+	return nil
+}
+
+// selectionToggler is implemented by widgets which may be made selectable.
+type selectionToggler interface {
+	selectionEnabled() bool
+}
+
+// updateSelectionOwner makes a widget which is clicked or dragged over the
+// owner of the selection when it is selectable and has selected text.
+// Clicking a widget without selected text clears the selection.
+func (u *UI) updateSelectionOwner(w Widget, clicked bool) {
+	selector := findTextSelector(w)
+	if selector != nil {
+		toggler, ok := selector.(selectionToggler)
+		if !ok || toggler.selectionEnabled() {
+			start, end := selector.Selection()
+			if start != end {
+				u.setSelectionOwner(w)
+				return
+			}
+		}
+	}
+	if clicked {
+		u.setSelectionOwner(nil)
+	}
+}
+
+// setSelectionOwner sets the widget which owns the selection. The selection
+// of the previous owner is cleared.
+func (u *UI) setSelectionOwner(w Widget) {
+	if w == u.selectionOwner {
+		return
+	}
+	if u.selectionOwner != nil {
+		selector := findTextSelector(u.selectionOwner)
+		if selector != nil {
+			_, end := selector.Selection()
+			selector.SetSelection(end, end)
+			MarkDirty(u.selectionOwner)
+		}
+	}
+	u.selectionOwner = w
+}
@@ -0,0 +1,101 @@
+package etk_test
+
+import (
+	"testing"
+
+	"codeberg.org/tslocum/etk"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestTextSelection(t *testing.T) {
+	setupStyle(t)
+
+	clipboard := &etk.MemoryClipboard{}
+	etk.SetClipboard(clipboard)
+	defer etk.SetClipboard(nil)
+
+	if cursor := etk.NewText("Label").Cursor(); cursor != ebiten.CursorShapeDefault {
+		t.Fatalf("text is selectable by default: cursor %v", cursor)
+	}
+
+	label := etk.NewText("Hello world")
+	label.SetSelectable(true)
+	input := etk.NewInput("Input", nil, nil)
+	plain := etk.NewText("Plain")
+	g := etk.NewGrid()
+	g.AddChildAt(label, 0, 0, 1, 1)
+	g.AddChildAt(input, 1, 0, 1, 1)
+	g.AddChildAt(plain, 2, 0, 1, 1)
+	u, f, _, _ := setupFakeInput(t, g)
+	u.Layout(400, 100)
+	u.SetFocus(input)
+
+	label.SetSelection(6, 11)
+	if text := label.SelectedText(); text != "world" {
+		t.Fatalf("unexpected selection: expected world, got %q", text)
+	}
+
+	click := func(w etk.Widget, clicks int) {
+		r := w.Rect()
+		f.MoveCursor(r.Min.X+1, r.Min.Y+1)
+		for i := 0; i < clicks; i++ {
+			f.PressMouse(ebiten.MouseButtonLeft)
+			frame(t, u, f)
+			f.ReleaseMouse(ebiten.MouseButtonLeft)
+			frame(t, u, f)
+		}
+	}
+	press := func(modifier ebiten.Key, key ebiten.Key) {
+		f.PressKey(modifier)
+		f.PressKey(key)
+		frame(t, u, f)
+		f.ReleaseKey(key)
+		f.ReleaseKey(modifier)
+		frame(t, u, f)
+	}
+
+	// Double-click the label to select a word and copy it while the input
+	// remains focused.
+	click(label, 2)
+	if text := label.SelectedText(); text != "Hello" {
+		t.Fatalf("unexpected selection after double click: expected Hello, got %q", text)
+	} else if u.Focused() != input {
+		t.Fatal("input lost focus")
+	}
+	press(ebiten.KeyControl, ebiten.KeyC)
+	text, err := clipboard.ReadText()
+	if err != nil {
+		t.Fatal(err)
+	} else if string(text) != "Hello" {
+		t.Fatalf("unexpected copy result: expected Hello, got %q", text)
+	}
+
+	// Clicking the input clears the selection of the label.
+	click(input, 1)
+	if text := label.SelectedText(); text != "" {
+		t.Fatalf("label selection was not cleared: %q", text)
+	}
+	press(ebiten.KeyShift, ebiten.KeyArrowRight)
+	press(ebiten.KeyShift, ebiten.KeyArrowRight)
+	if start, end := input.Selection(); start != 0 || end != 2 {
+		t.Fatalf("unexpected input selection: expected 0-2, got %d-%d", start, end)
+	}
+	press(ebiten.KeyControl, ebiten.KeyC)
+	text, err = clipboard.ReadText()
+	if err != nil {
+		t.Fatal(err)
+	} else if string(text) != "In" {
+		t.Fatalf("unexpected copy result: expected In, got %q", text)
+	}
+
+	// Clicking text which is not selectable does not make it the owner of the
+	// selection.
+	click(plain, 1)
+	press(ebiten.KeyControl, ebiten.KeyC)
+	text, err = clipboard.ReadText()
+	if err != nil {
+		t.Fatal(err)
+	} else if string(text) == "Plain" {
+		t.Fatal("text which is not selectable was copied")
+	}
+}
@@ -0,0 +1,110 @@
+package etk
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ShortcutPriority specifies when a shortcut is checked relative to the
+// focused widget receiving the key.
+type ShortcutPriority int
+
+// Shortcut priorities.
+const (
+	// ShortcutBeforeFocused checks the shortcut before the key is passed to
+	// the focused widget. When the shortcut is triggered, the key is not passed
+	// to the focused widget.
+	ShortcutBeforeFocused ShortcutPriority = iota
+
+	// ShortcutAfterFocused checks the shortcut after the key is passed to the
+	// focused widget. The shortcut is only triggered when the focused widget
+	// does not handle the key, or when no widget is focused.
+	ShortcutAfterFocused
+)
+
+// Shortcut is an application-level keyboard shortcut. Shortcuts are triggered
+// regardless of which widget is focused. Register a shortcut via AddShortcut.
+type Shortcut struct {
+	// Key is the key which triggers the shortcut.
+	Key ebiten.Key
+
+	// Modifier keys which must be held when the key is pressed. Modifier keys
+	// which are not specified must not be held.
+	Ctrl  bool
+	Shift bool
+	Alt   bool
+	Meta  bool
+
+	// Priority specifies when the shortcut is checked relative to the focused
+	// widget receiving the key.
+	Priority ShortcutPriority
+
+	// Scope is the widget which must be visible for the shortcut to be active.
+	// A widget is visible when it and all of its ancestors are visible, and it
+	// is a descendant of the root widget. When Scope is nil, the shortcut is
+	// always active.
+	Scope Widget
+
+	// Action is the function called when the shortcut is triggered.
+	Action func() error
+}
+
+// AddShortcut registers a shortcut with the default UI. See UI.AddShortcut.
+func AddShortcut(s *Shortcut) {
+	activeUI().AddShortcut(s)
+}
+
+// AddShortcut registers a shortcut. Shortcuts registered later are checked
+// before shortcuts registered earlier. Only one shortcut is triggered per key.
+func (u *UI) AddShortcut(s *Shortcut) {
+	u.shortcuts = append(u.shortcuts, s)
+}
+
+// RemoveShortcut unregisters a shortcut from the default UI. See
+// UI.RemoveShortcut.
+func RemoveShortcut(s *Shortcut) {
+	activeUI().RemoveShortcut(s)
+}
+
+// RemoveShortcut unregisters a shortcut.
+func (u *UI) RemoveShortcut(s *Shortcut) {
+	for i, shortcut := range u.shortcuts {
+		if shortcut == s {
+			u.shortcuts = append(u.shortcuts[:i], u.shortcuts[i+1:]...)
+			return
+		}
+	}
+}
+
+// handleShortcuts triggers the most recently registered active shortcut which
+// matches the provided key event and priority.
+func (u *UI) handleShortcuts(e KeyEvent, priority ShortcutPriority) (handled bool, err error) {
+	for i := len(u.shortcuts) - 1; i >= 0; i-- {
+		s := u.shortcuts[i]
+		if s.Priority != priority || s.Key != e.Key || s.Ctrl != e.Ctrl || s.Shift != e.Shift || s.Alt != e.Alt || s.Meta != e.Meta {
+			continue
+		} else if s.Scope != nil && !shown(u.activeRoot(), s.Scope) {
+			continue
+		}
+		if s.Action == nil {
+			return true, nil
+		}
+		return true, s.Action()
+	}
+	return false, nil
+}
+
+// shown returns whether the target widget is a visible descendant of the
+// provided widget.
+func shown(w Widget, target Widget) bool {
+	if w == nil || !w.Visible() {
+		return false
+	} else if w == target {
+		return true
+	}
+	for _, child := range w.Children() {
+		if shown(child, target) {
+			return true
+		}
+	}
+	return false
+}
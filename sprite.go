@@ -39,6 +39,7 @@ func (s *Sprite) SetImage(img *ebiten.Image) {
 	s.img = img
 	s.imgBounds = s.img.Bounds()
 	s.thumbBounds = image.Rectangle{}
+	MarkDirty(s)
 }
 
 // SetHorizontal sets the horizontal alignment of the Sprite.
@@ -2,6 +2,7 @@ package etk
 
 import (
 	"image/color"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
@@ -18,6 +19,8 @@ type Attributes struct {
 
 	TextBgColor color.RGBA
 
+	TextSelectionColor color.RGBA
+
 	InputBorderSize      int
 	InputBorderFocused   color.RGBA
 	InputBorderUnfocused color.RGBA
@@ -33,9 +36,12 @@ type Attributes struct {
 
 	InputBgColor color.RGBA
 
+	TextAreaLineNumberColor color.RGBA
+
 	ButtonTextColor       color.RGBA
 	ButtonBgColor         color.RGBA
 	ButtonBgColorDisabled color.RGBA
+	ButtonBgColorHover    color.RGBA
 
 	ButtonBorderSize   int
 	ButtonBorderTop    color.RGBA
@@ -43,7 +49,28 @@ type Attributes struct {
 	ButtonBorderBottom color.RGBA
 	ButtonBorderLeft   color.RGBA
 
-	CheckboxBgColor color.RGBA
+	ButtonBorderFocused color.RGBA
+
+	CheckboxBgColor      color.RGBA
+	CheckboxBgColorHover color.RGBA
+
+	SelectBgColorHover color.RGBA
+
+	ListHoverColor color.RGBA
+
+	ModalBackdropColor color.RGBA
+
+	TooltipTextColor color.RGBA
+	TooltipBgColor   color.RGBA
+
+	ContextMenuTextColor color.RGBA
+	ContextMenuBgColor   color.RGBA
+
+	TransitionDuration time.Duration
+
+	SelectTransition Transition
+	WindowTransition Transition
+	ModalTransition  Transition
 }
 
 // Style is the current default attribute configuration. Integer values will be scaled.
@@ -55,6 +82,8 @@ var Style = &Attributes{
 
 	TextBgColor: transparent,
 
+	TextSelectionColor: color.RGBA{0, 96, 160, 255},
+
 	InputBorderSize:      2,
 	InputBorderFocused:   color.RGBA{220, 220, 220, 255},
 	InputBorderUnfocused: color.RGBA{0, 0, 0, 255},
@@ -70,8 +99,11 @@ var Style = &Attributes{
 
 	InputBgColor: color.RGBA{0, 64, 0, 255},
 
+	TextAreaLineNumberColor: color.RGBA{150, 170, 150, 255},
+
 	ButtonBgColor:         color.RGBA{255, 255, 255, 255},
 	ButtonBgColorDisabled: color.RGBA{110, 110, 110, 255},
+	ButtonBgColorHover:    color.RGBA{235, 235, 235, 255},
 
 	ButtonBorderSize:   4,
 	ButtonBorderTop:    color.RGBA{220, 220, 220, 255},
@@ -79,5 +111,26 @@ var Style = &Attributes{
 	ButtonBorderBottom: color.RGBA{0, 0, 0, 255},
 	ButtonBorderLeft:   color.RGBA{220, 220, 220, 255},
 
-	CheckboxBgColor: color.RGBA{255, 255, 255, 255},
+	ButtonBorderFocused: color.RGBA{70, 130, 180, 255},
+
+	CheckboxBgColor:      color.RGBA{255, 255, 255, 255},
+	CheckboxBgColorHover: color.RGBA{235, 235, 235, 255},
+
+	SelectBgColorHover: color.RGBA{235, 235, 235, 255},
+
+	ListHoverColor: color.RGBA{192, 192, 192, 255},
+
+	ModalBackdropColor: color.RGBA{0, 0, 0, 128},
+
+	TooltipTextColor: color.RGBA{0, 0, 0, 255},
+	TooltipBgColor:   color.RGBA{255, 255, 225, 255},
+
+	ContextMenuTextColor: color.RGBA{0, 0, 0, 255},
+	ContextMenuBgColor:   color.RGBA{255, 255, 255, 255},
+
+	TransitionDuration: 150 * time.Millisecond,
+
+	SelectTransition: TransitionSlide,
+	WindowTransition: TransitionFade,
+	ModalTransition:  TransitionFade,
 }
@@ -16,6 +16,8 @@ type Text struct {
 	textFont      *text.GoTextFaceSource
 	textSize      int
 	scrollVisible bool
+	selectable    bool
+	mask          rune
 	children      []Widget
 }
 
@@ -123,15 +125,67 @@ func (t *Text) SetVertical(v Alignment) {
 // Cursor returns the cursor shape shown when a mouse cursor hovers over the
 // widget, or -1 to let widgets beneath determine the cursor shape.
 func (t *Text) Cursor() ebiten.CursorShapeType {
+	if t.selectable {
+		return ebiten.CursorShapeText
+	}
 	return ebiten.CursorShapeDefault
 }
 
+// SetSelectable sets whether text may be selected by clicking and dragging,
+// double-clicking to select a word or triple-clicking to select a line. Text
+// is not selectable by default. Text which is selectable may not be scrolled
+// by dragging it.
+func (t *Text) SetSelectable(selectable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.selectable = selectable
+	t.field.SetSelectable(selectable)
+	MarkDirty(t)
+}
+
+// selectionEnabled returns whether text may be selected.
+func (t *Text) selectionEnabled() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.selectable
+}
+
+// Selection returns the bounds of the selected text, in bytes from the start
+// of the text. When no text is selected, start and end are equal.
+func (t *Text) Selection() (start int, end int) {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.field.Selection()
+}
+
+// SetSelection selects the text between the provided positions, in bytes from
+// the start of the text.
+func (t *Text) SetSelection(start int, end int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.field.SetSelection(start, end)
+	MarkDirty(t)
+}
+
+// SelectedText returns the selected text.
+func (t *Text) SelectedText() string {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.field.SelectedText()
+}
+
 // Write writes to the text buffer.
 func (t *Text) Write(p []byte) (n int, err error) {
 	t.Lock()
 	defer t.Unlock()
 
 	n, err = t.field.Write(p)
+	MarkDirty(t)
 	if err != nil {
 		return n, err
 	}
@@ -146,12 +200,32 @@ func (t *Text) Text() string {
 	return t.field.Text()
 }
 
+// Copy copies the selected text to the clipboard, or all text in the field
+// when no text is selected. Masked text is not copied. Text widgets do not
+// receive focus, so Copy is only called when Ctrl+C is pressed after text is
+// selected. Copy may also be called by the application, such as from a
+// ContextMenu action.
+func (t *Text) Copy() error {
+	t.Lock()
+	text, mask := t.field.SelectedText(), t.mask
+	if text == "" {
+		text = t.field.Text()
+	}
+	t.Unlock()
+
+	if mask != 0 || text == "" {
+		return nil
+	}
+	return WriteClipboard([]byte(text))
+}
+
 // SetText sets the text in the field.
 func (t *Text) SetText(text string) {
 	t.Lock()
 	defer t.Unlock()
 
 	t.field.SetText(text)
+	MarkDirty(t)
 }
 
 // SetLast sets the text of the last line of the field.
@@ -160,6 +234,7 @@ func (t *Text) SetLast(text string) {
 	defer t.Unlock()
 
 	t.field.SetLast(text)
+	MarkDirty(t)
 }
 
 // SetAutoResize sets whether the font is automatically scaled down when it is
@@ -259,9 +334,21 @@ func (t *Text) SetMask(r rune) {
 	t.Lock()
 	defer t.Unlock()
 
+	t.mask = r
 	t.field.SetMask(r)
 }
 
+// Accessibility returns the role, name, value and state of the widget.
+func (t *Text) Accessibility() Accessibility {
+	t.Lock()
+	defer t.Unlock()
+
+	return Accessibility{
+		Role: RoleText,
+		Name: maskText(t.field.Text(), t.mask),
+	}
+}
+
 // HandleKeyboard is called when a keyboard event occurs.
 func (t *Text) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
 	return t.field.HandleKeyboardEvent(key, r)
@@ -272,6 +359,11 @@ func (t *Text) HandleMouse(cursor image.Point, pressed bool, clicked bool) (hand
 	return t.field.HandleMouseEvent(cursor, pressed, clicked)
 }
 
+// HandleScroll is called when the mouse wheel is scrolled over the widget.
+func (t *Text) HandleScroll(x float64, y float64) (handled bool, err error) {
+	return t.field.HandleScrollEvent(x, y)
+}
+
 // Draw draws the widget on the screen.
 func (t *Text) Draw(screen *ebiten.Image) error {
 	t.field.Draw(screen)
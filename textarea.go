@@ -0,0 +1,313 @@
+package etk
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// TextArea is a multi-line text editing widget. Content which does not fit
+// within the widget is scrolled vertically, and the view follows the caret as
+// it moves. Line numbers may be shown in a gutter via SetLineNumbers, and
+// pressing Tab inserts an indent when one is set via SetIndent.
+type TextArea struct {
+	*Box
+	editField
+	indent string
+}
+
+// NewTextArea returns a new TextArea widget.
+func NewTextArea(text string, onChange func(text string, r rune) (accept bool)) *TextArea {
+	box := NewBox()
+	t := &TextArea{
+		Box:       box,
+		editField: newEditField(box, text, onChange),
+	}
+	f := t.field
+	f.SetLineNumberColor(Style.TextAreaLineNumberColor)
+	f.SetFollow(false)
+	f.SetEditFunc(t.editFunc())
+	return t
+}
+
+// SetLineNumbers sets whether line numbers are shown in a gutter at the start
+// of each line. Lines which are wrapped are numbered once.
+func (t *TextArea) SetLineNumbers(show bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.field.SetLineNumbers(show)
+	MarkDirty(t)
+}
+
+// SetLineNumberColor sets the color of the line numbers.
+func (t *TextArea) SetLineNumberColor(c color.RGBA) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.field.SetLineNumberColor(c)
+	MarkDirty(t)
+}
+
+// SetIndent sets the text inserted when Tab is pressed, such as a tab
+// character or a number of spaces. When multiple lines are selected, each
+// line is indented. Shift+Tab removes one level of indentation, and new lines
+// keep the indentation of the line before them. While an indent is set, focus
+// is moved by pressing Ctrl+Tab instead of Tab. Set to an empty string to
+// disable, which is the default.
+func (t *TextArea) SetIndent(indent string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.indent = indent
+	t.field.SetIndent(indent)
+}
+
+// CaptureFocusKeys returns whether the focus keys are passed to the widget
+// instead of moving focus, which is the case when an indent is set.
+func (t *TextArea) CaptureFocusKeys() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.indent != ""
+}
+
+// SetFocus sets the focus state of the widget.
+func (t *TextArea) SetFocus(focus bool) bool {
+	t.focused = focus
+	t.field.SetCaretVisible(focus)
+	return true
+}
+
+// Accessibility returns the role, name, value and state of the widget.
+func (t *TextArea) Accessibility() Accessibility {
+	t.Lock()
+	defer t.Unlock()
+
+	return Accessibility{
+		Role:    RoleTextbox,
+		Value:   t.field.Text(),
+		Focused: t.focused,
+	}
+}
+
+// Copy copies the selected text to the clipboard.
+func (t *TextArea) Copy() error {
+	text := t.SelectedText()
+	if text == "" {
+		return nil
+	}
+	return WriteClipboard([]byte(text))
+}
+
+// Cut copies the selected text to the clipboard and removes it from the field.
+func (t *TextArea) Cut() error {
+	t.Lock()
+	text := t.field.Text()
+	start, end := t.field.Selection()
+	t.Unlock()
+
+	return t.cut(text, start, end)
+}
+
+// SetRect sets the position and size of the widget.
+func (t *TextArea) SetRect(r image.Rectangle) {
+	t.setRect(r)
+}
+
+// SetBorderSize sets the size of the border around the field.
+func (t *TextArea) SetBorderSize(size int) {
+	t.setBorderSize(size)
+}
+
+// SetBorderColors sets the border colors of the field when focused and unfocused.
+func (t *TextArea) SetBorderColors(focused color.RGBA, unfocused color.RGBA) {
+	t.setBorderColors(focused, unfocused)
+}
+
+// Foreground return the color of the text within the field.
+func (t *TextArea) Foreground() color.RGBA {
+	return t.foreground()
+}
+
+// SetForeground sets the color of the text within the field.
+func (t *TextArea) SetForeground(c color.RGBA) {
+	t.setForeground(c)
+}
+
+// Focus returns the focus state of the widget.
+func (t *TextArea) Focus() bool {
+	return t.focus()
+}
+
+// Caret returns the position of the insertion point, in bytes from the start
+// of the text.
+func (t *TextArea) Caret() int {
+	return t.caret()
+}
+
+// SetCaret sets the position of the insertion point, in bytes from the start
+// of the text. The position is moved to the nearest valid position within the
+// text, and the field is scrolled to show the caret.
+func (t *TextArea) SetCaret(pos int) {
+	t.setCaret(pos)
+}
+
+// Text returns the content of the text buffer.
+func (t *TextArea) Text() string {
+	return t.text()
+}
+
+// SetText sets the text in the field.
+func (t *TextArea) SetText(text string) {
+	t.setText(text)
+}
+
+// Undo undoes the last edit. It returns whether an edit was undone. Edits may
+// also be undone by pressing Ctrl+Z.
+func (t *TextArea) Undo() bool {
+	return t.undo()
+}
+
+// Redo redoes the last edit which was undone. It returns whether an edit was
+// redone. Edits may also be redone by pressing Ctrl+Y or Ctrl+Shift+Z.
+func (t *TextArea) Redo() bool {
+	return t.redo()
+}
+
+// SetHistorySize sets the maximum number of edits which may be undone. A size
+// of 0 disables undo and redo. The default size is 100.
+func (t *TextArea) SetHistorySize(size int) {
+	t.setHistorySize(size)
+}
+
+// SetClearHistoryOnSetText sets whether the edit history is cleared when the
+// text is set via SetText. When disabled, setting the text is recorded as an
+// edit which may be undone. The history is cleared by default.
+func (t *TextArea) SetClearHistoryOnSetText(clear bool) {
+	t.setClearHistoryOnSetText(clear)
+}
+
+// ClearHistory clears the edit history.
+func (t *TextArea) ClearHistory() {
+	t.clearHistory()
+}
+
+// SetScrollBarWidth sets the width of the scroll bar.
+func (t *TextArea) SetScrollBarWidth(width int) {
+	t.setScrollBarWidth(width)
+}
+
+// SetScrollBarColors sets the color of the scroll bar area and handle.
+func (t *TextArea) SetScrollBarColors(area color.RGBA, handle color.RGBA) {
+	t.setScrollBarColors(area, handle)
+}
+
+// SetScrollBarVisible sets whether the scroll bar is visible on the screen.
+func (t *TextArea) SetScrollBarVisible(scrollVisible bool) {
+	t.setScrollBarVisible(scrollVisible)
+}
+
+// SetAutoHideScrollBar sets whether the scroll bar is automatically hidden
+// when the entire text buffer is visible.
+func (t *TextArea) SetAutoHideScrollBar(autoHide bool) {
+	t.setAutoHideScrollBar(autoHide)
+}
+
+// SetFont sets the font and text size of the field. Scaling is not applied.
+func (t *TextArea) SetFont(fnt *text.GoTextFaceSource, size int) {
+	t.setFont(fnt, size)
+}
+
+// Padding returns the amount of padding around the text within the field.
+func (t *TextArea) Padding() int {
+	return t.padding()
+}
+
+// SetPadding sets the amount of padding around the text within the field.
+func (t *TextArea) SetPadding(padding int) {
+	t.setPadding(padding)
+}
+
+// SetWordWrap sets a flag which, when enabled, causes text to wrap without breaking words.
+func (t *TextArea) SetWordWrap(wrap bool) {
+	t.setWordWrap(wrap)
+}
+
+// SetChangeFunc sets the handler called when the text changes. The text as it
+// will be after the change is passed along with the rune which was entered.
+// When text is deleted, cut or indented, a rune value of 0 is passed. The
+// handler may return false to reject the change.
+func (t *TextArea) SetChangeFunc(onChange func(text string, r rune) (accept bool)) {
+	t.setChangeFunc(onChange)
+}
+
+// Cursor returns the cursor shape shown when a mouse cursor hovers over the
+// widget, or -1 to let widgets beneath determine the cursor shape.
+func (t *TextArea) Cursor() ebiten.CursorShapeType {
+	return t.cursorShape()
+}
+
+// Selection returns the bounds of the selected text, in bytes from the start
+// of the text. When no text is selected, start and end are both the position
+// of the caret.
+func (t *TextArea) Selection() (start int, end int) {
+	return t.selection()
+}
+
+// SetSelection selects the text between the provided positions, in bytes from
+// the start of the text, and moves the caret to end.
+func (t *TextArea) SetSelection(start int, end int) {
+	t.setSelection(start, end)
+}
+
+// SelectedText returns the selected text.
+func (t *TextArea) SelectedText() string {
+	return t.selectedText()
+}
+
+// Write writes to the text buffer at the insertion point, replacing the
+// selected text.
+func (t *TextArea) Write(p []byte) (n int, err error) {
+	return t.write(p)
+}
+
+// CaretBounds returns the location of the insertion point on the screen.
+func (t *TextArea) CaretBounds() image.Rectangle {
+	return t.caretBounds()
+}
+
+// SetComposition sets the text being composed via an input method editor. The
+// composition is shown underlined at the insertion point.
+func (t *TextArea) SetComposition(text string) {
+	t.setComposition(text)
+}
+
+// HandleKeyboard is called when a keyboard event occurs.
+func (t *TextArea) HandleKeyboard(key ebiten.Key, r rune) (handled bool, err error) {
+	return t.handleKeyboard(key, r)
+}
+
+// HandleKeyEvent is called when a key is pressed or released, or a rune is
+// entered. Modifier keys are passed to the field so that word movement,
+// selection and deletion may be handled.
+func (t *TextArea) HandleKeyEvent(event KeyEvent) (handled bool, err error) {
+	return t.handleKeyEvent(event)
+}
+
+// HandleMouse is called when a mouse event occurs.
+func (t *TextArea) HandleMouse(cursor image.Point, pressed bool, clicked bool) (handled bool, err error) {
+	return t.handleMouse(cursor, pressed, clicked)
+}
+
+// HandleScroll is called when the mouse wheel is scrolled over the widget.
+func (t *TextArea) HandleScroll(x float64, y float64) (handled bool, err error) {
+	return t.handleScroll(x, y)
+}
+
+// Draw draws the widget on the screen.
+func (t *TextArea) Draw(screen *ebiten.Image) error {
+	return t.draw(screen)
+}
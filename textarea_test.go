@@ -0,0 +1,56 @@
+package etk_test
+
+import (
+	"testing"
+
+	"codeberg.org/tslocum/etk"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestTextAreaFocusKeys(t *testing.T) {
+	setupStyle(t)
+
+	area := etk.NewTextArea("x", nil)
+	input := etk.NewInput("", nil, nil)
+	g := etk.NewGrid()
+	g.AddChildAt(area, 0, 0, 1, 1)
+	g.AddChildAt(input, 1, 0, 1, 1)
+	u, f, _, _ := setupFakeInput(t, g)
+	u.Layout(400, 200)
+
+	press := func(modifier ebiten.Key, key ebiten.Key) {
+		if modifier != -1 {
+			f.PressKey(modifier)
+		}
+		f.PressKey(key)
+		frame(t, u, f)
+		f.ReleaseKey(key)
+		if modifier != -1 {
+			f.ReleaseKey(modifier)
+		}
+		frame(t, u, f)
+	}
+
+	// Tab moves focus when no indent is set.
+	u.SetFocus(area)
+	press(-1, ebiten.KeyTab)
+	if u.Focused() != input {
+		t.Fatal("tab did not move focus from text area without an indent")
+	} else if text := area.Text(); text != "x" {
+		t.Fatalf("unexpected text area text: %q", text)
+	}
+
+	// Tab inserts the indent when one is set, and Ctrl+Tab moves focus.
+	area.SetIndent("\t")
+	u.SetFocus(area)
+	press(-1, ebiten.KeyTab)
+	if u.Focused() != area {
+		t.Fatal("tab moved focus from text area with an indent")
+	} else if text := area.Text(); text != "x\t" {
+		t.Fatalf("unexpected text area text: expected %q, got %q", "x\t", text)
+	}
+	press(ebiten.KeyControl, ebiten.KeyTab)
+	if u.Focused() != input {
+		t.Fatal("ctrl+tab did not move focus from text area")
+	}
+}
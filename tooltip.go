@@ -0,0 +1,158 @@
+package etk
+
+import (
+	"image"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tooltipper may be implemented by widgets to show tooltip text when the mouse
+// cursor hovers over the widget, or when the widget is long-pressed on a touch
+// screen. Return an empty string to show no tooltip.
+type Tooltipper interface {
+	// Tooltip returns the tooltip text of the widget.
+	Tooltip() string
+}
+
+// WithTooltip wraps a widget to show tooltip text when the mouse cursor hovers
+// over it, or when it is long-pressed on a touch screen.
+type WithTooltip struct {
+	Widget
+	Text string
+}
+
+// Tooltip returns the tooltip text of the widget.
+func (w *WithTooltip) Tooltip() string {
+	return w.Text
+}
+
+type tooltipState struct {
+	widget Widget
+	text   string
+	start  time.Time
+	touch  bool
+	shown  bool
+	cursor image.Point
+	label  *Text
+
+	labelText string
+}
+
+// tooltipAt returns the topmost widget with tooltip text at the provided
+// screen location.
+func tooltipAt(w Widget, p image.Point) (Widget, string) {
+	if w == nil || !w.Visible() {
+		return nil, ""
+	}
+
+	children := w.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		result, label := tooltipAt(children[i], p)
+		if result != nil {
+			return result, label
+		}
+	}
+
+	if !p.In(w.Rect()) {
+		return nil, ""
+	}
+	t, ok := w.(Tooltipper)
+	if !ok {
+		return nil, ""
+	}
+	label := t.Tooltip()
+	if label == "" {
+		return nil, ""
+	}
+	return w, label
+}
+
+// updateTooltip shows or hides the tooltip. Mouse cursor tooltips are shown
+// after hovering over a widget for Bindings.TooltipDelay. Touch screen tooltips
+// are shown after pressing a widget for Bindings.LongPressThreshold.
+func (u *UI) updateTooltip(cursor image.Point, pressed bool, clicked bool, touch bool, hover bool) {
+	t := &u.tooltip
+	defer func(shown bool) {
+		if t.shown != shown {
+			u.invalidate()
+		}
+	}(t.shown)
+
+	var w Widget
+	var label string
+	if touch || hover {
+		w, label = tooltipAt(u.activeRoot(), cursor)
+	}
+	if w != t.widget || label != t.text || touch != t.touch || clicked {
+		t.widget, t.text, t.touch = w, label, touch
+		t.start = time.Now()
+		t.shown = false
+	}
+	if w == nil || (!touch && pressed) {
+		t.shown = false
+		return
+	}
+
+	delay := Bindings.TooltipDelay
+	if touch {
+		delay = Bindings.LongPressThreshold
+	}
+	if !t.shown && time.Since(t.start) >= delay {
+		t.shown = true
+		t.cursor = cursor
+	}
+}
+
+// drawTooltip draws the tooltip near the mouse cursor, within the screen.
+func (u *UI) drawTooltip(screen *ebiten.Image) error {
+	t := &u.tooltip
+	if !t.shown {
+		return nil
+	}
+	if t.label == nil {
+		t.label = NewText("")
+		t.label.SetScrollBarVisible(false)
+		t.label.SetWordWrap(false)
+	}
+	if t.labelText != t.text {
+		t.label.SetBackground(Style.TooltipBgColor)
+		t.label.SetForeground(Style.TooltipTextColor)
+		t.label.SetText(t.text)
+		t.labelText = t.text
+	}
+
+	face := FontFace(Style.TextFont, Scale(Style.TextSize))
+	padding := t.label.Padding()
+	var width int
+	lines := strings.Split(t.text, "\n")
+	for _, line := range lines {
+		bounds := BoundString(face, line)
+		if bounds.Dx() > width {
+			width = bounds.Dx()
+		}
+	}
+	m := face.Metrics()
+	width += padding*2 + 2
+	height := int(m.HAscent+m.HDescent)*len(lines) + padding*2
+
+	// Position the tooltip below the cursor, keeping it within the screen.
+	offset := Scale(16)
+	x, y := t.cursor.X+offset/2, t.cursor.Y+offset
+	screenWidth, screenHeight := u.ScreenSize()
+	if x+width > screenWidth {
+		x = screenWidth - width
+	}
+	if y+height > screenHeight {
+		y = t.cursor.Y - offset - height
+	}
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	t.label.SetRect(image.Rect(x, y, x+width, y+height))
+	return u.draw(t.label, screen)
+}
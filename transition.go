@@ -0,0 +1,186 @@
+package etk
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Transition is an animation shown when a widget appears or disappears.
+type Transition int
+
+// Transitions.
+const (
+	// TransitionNone shows and hides widgets immediately.
+	TransitionNone Transition = iota
+
+	// TransitionFade fades widgets in and out.
+	TransitionFade
+
+	// TransitionSlide slides widgets into place from below while fading them
+	// in, and slides them back down while fading them out.
+	TransitionSlide
+)
+
+// transitionSlideDistance is the distance in pixels which widgets are moved by
+// TransitionSlide. The distance is scaled.
+const transitionSlideDistance = 16
+
+// drawEffect modifies how a widget and its children are drawn.
+type drawEffect struct {
+	alpha   float64
+	offset  image.Point
+	leaving bool
+	anim    *Animation
+	image   *ebiten.Image
+}
+
+// effect returns the draw effect of a widget, creating it as needed.
+func (u *UI) effect(w Widget) *drawEffect {
+	fx := u.effects[w]
+	if fx == nil {
+		if u.effects == nil {
+			u.effects = make(map[Widget]*drawEffect)
+		}
+		fx = &drawEffect{alpha: 1}
+		u.effects[w] = fx
+	}
+	return fx
+}
+
+// clearEffect removes the draw effect of a widget when it no longer modifies
+// how the widget is drawn.
+func (u *UI) clearEffect(w Widget, force bool) {
+	fx := u.effects[w]
+	if fx == nil || (!force && (fx.alpha < 1 || fx.offset != image.Point{} || fx.leaving)) {
+		return
+	}
+	if fx.anim != nil {
+		fx.anim.Stop()
+	}
+	if fx.image != nil {
+		fx.image.Deallocate()
+	}
+	delete(u.effects, w)
+	u.invalidate()
+}
+
+// Alpha returns the opacity of a widget within the active UI. See UI.Alpha.
+func Alpha(w Widget) float64 {
+	return activeUI().Alpha(w)
+}
+
+// Alpha returns the opacity at which a widget and its children are drawn.
+func (u *UI) Alpha(w Widget) float64 {
+	fx := u.effects[w]
+	if fx == nil {
+		return 1
+	}
+	return fx.alpha
+}
+
+// SetAlpha sets the opacity of a widget within the active UI. See
+// UI.SetAlpha.
+func SetAlpha(w Widget, alpha float64) {
+	activeUI().SetAlpha(w, alpha)
+}
+
+// SetAlpha sets the opacity at which a widget and its children are drawn, from
+// 0 (transparent) to 1 (opaque). Transparent widgets still receive user input.
+func (u *UI) SetAlpha(w Widget, alpha float64) {
+	u.effect(w).alpha = max(0, min(alpha, 1))
+	u.clearEffect(w, false)
+	MarkDirty(w)
+}
+
+// SetDrawOffset sets the drawing offset of a widget within the active UI. See
+// UI.SetDrawOffset.
+func SetDrawOffset(w Widget, offset image.Point) {
+	activeUI().SetDrawOffset(w, offset)
+}
+
+// SetDrawOffset sets the distance in pixels by which a widget and its children
+// are moved when they are drawn. The area where the widget receives user input
+// is not moved.
+func (u *UI) SetDrawOffset(w Widget, offset image.Point) {
+	u.effect(w).offset = offset
+	u.clearEffect(w, false)
+	MarkDirty(w)
+}
+
+// transition animates a widget appearing or disappearing. A widget which
+// disappears should be hidden before calling transition. It continues to be
+// drawn, without receiving user input, until the transition completes.
+func (u *UI) transition(w Widget, t Transition, appear bool) {
+	if w == nil {
+		return
+	}
+	if t == TransitionNone || Style.TransitionDuration <= 0 || ReducedMotion() {
+		u.clearEffect(w, true)
+		return
+	}
+	fx := u.effect(w)
+	if fx.anim != nil {
+		fx.anim.Stop()
+	}
+	var distance int
+	if t == TransitionSlide {
+		distance = Scale(transitionSlideDistance)
+	}
+	from, to := 0.0, 1.0
+	if !appear {
+		from, to = fx.alpha, 0.0
+	}
+	set := func(alpha float64) {
+		fx.alpha = alpha
+		fx.offset = image.Point{Y: int(math.Round(float64(distance) * (1 - alpha)))}
+	}
+	set(from)
+	fx.leaving = !appear
+	fx.anim = u.Animate(Style.TransitionDuration, EaseOutQuad, func(v float64) {
+		set(TweenFloat(from, to, v))
+		MarkDirty(w)
+	}, func() {
+		u.clearEffect(w, true)
+	})
+}
+
+// drawn returns whether a widget is drawn. Widgets are drawn while they are
+// visible, and while they are disappearing via a transition.
+func (u *UI) drawn(w Widget) bool {
+	if w == nil {
+		return false
+	} else if w.Visible() {
+		return true
+	}
+	fx := u.effects[w]
+	return fx != nil && fx.leaving
+}
+
+// drawEffect draws a widget and its children to an offscreen image, which is
+// then drawn to the screen with the widget's draw effect applied.
+func (u *UI) drawEffect(w Widget, fx *drawEffect, screen *ebiten.Image) error {
+	r := w.Rect().Intersect(screen.Bounds())
+	if r.Empty() {
+		return u.drawLayer(w, screen)
+	}
+	if fx.image == nil || fx.image.Bounds() != r {
+		if fx.image != nil {
+			fx.image.Deallocate()
+		}
+		fx.image = ebiten.NewImageWithOptions(r, nil)
+	} else {
+		fx.image.Clear()
+	}
+	err := u.drawLayer(w, fx.image)
+	if err != nil {
+		return err
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(r.Min.X+fx.offset.X), float64(r.Min.Y+fx.offset.Y))
+	op.ColorScale.ScaleAlpha(float32(fx.alpha))
+	screen.DrawImage(fx.image, op)
+	return nil
+}
@@ -0,0 +1,157 @@
+package etk
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// UI is an independent user interface. Each UI has its own root widget,
+// focused widget, modal widgets, shortcuts and input source, allowing multiple
+// user interfaces to be shown at once, such as one per player in a split-screen
+// game. Bindings and Style are shared by all user interfaces.
+//
+// The package-level functions Layout, Update, Draw, SetRoot, SetFocus and
+// others operate on the default UI. While a UI is being laid out, updated or
+// drawn, such as when a widget handles user input, the package-level functions
+// operate on that UI instead.
+type UI struct {
+	root Widget
+
+	input InputSource
+
+	drawDebug bool
+
+	lastWidth, lastHeight int
+
+	lastX, lastY             int
+	lastCursorX, lastCursorY int
+
+	touchIDs      []ebiten.TouchID
+	activeTouchID ebiten.TouchID
+	lastTouch     bool
+	longPress     longPressState
+
+	heldButton ebiten.MouseButton
+
+	focusedWidget Widget
+
+	pressedWidget Widget
+
+	selectionOwner Widget
+
+	hoveredWidget Widget
+	mouseWidget   Widget
+
+	cursorShape ebiten.CursorShapeType
+
+	foundFocused bool
+
+	dirty        bool
+	dirtyWidgets map[Widget]bool
+	cacheDepth   int
+
+	consumed ConsumedInput
+
+	lastResize time.Time
+
+	keyBuffer  []ebiten.Key
+	runeBuffer []rune
+
+	focusChain []Widget
+	keyPath    []Widget
+
+	gamepadIDs []ebiten.GamepadID
+
+	repeatKey  ebiten.Key
+	nextRepeat time.Time
+
+	shortcuts []*Shortcut
+
+	modals        []*modal
+	closingModals []*modal
+
+	tooltip tooltipState
+
+	drag dragState
+
+	ime imeState
+
+	animations []*Animation
+	effects    map[Widget]*drawEffect
+}
+
+// NewUI returns a new UI. User input is read from Ebitengine.
+func NewUI() *UI {
+	return &UI{
+		input:         ebitenInput{},
+		drawDebug:     strings.TrimSpace(os.Getenv("ETK_DEBUG")) == "1",
+		activeTouchID: -1,
+		heldButton:    -1,
+		dirty:         true,
+		repeatKey:     -1,
+	}
+}
+
+var defaultUI = NewUI()
+
+var currentUI *UI
+
+// DefaultUI returns the UI operated on by package-level functions.
+func DefaultUI() *UI {
+	return defaultUI
+}
+
+// activeUI returns the UI which is being laid out, updated or drawn, or the
+// default UI.
+func activeUI() *UI {
+	if currentUI != nil {
+		return currentUI
+	}
+	return defaultUI
+}
+
+// enter sets the UI operated on by package-level functions and returns a
+// function which restores the previous UI.
+func (u *UI) enter() func() {
+	previous := currentUI
+	currentUI = u
+	return func() {
+		currentUI = previous
+	}
+}
+
+// ConsumedInput reports which user input was consumed by widgets.
+type ConsumedInput struct {
+	// Mouse is true when the mouse cursor is over a widget which handles
+	// mouse input, a widget is being clicked or dragged, or a modal widget
+	// is shown.
+	Mouse bool
+
+	// Touch is true when a touch is over a widget which handles touch input,
+	// a widget is being pressed or dragged, or a modal widget is shown.
+	Touch bool
+
+	// Keyboard is true when a key press or typed character was handled by
+	// the focused widget, a shortcut or focus navigation.
+	Keyboard bool
+
+	// Gamepad is true when a gamepad button press was handled by the focused
+	// widget or focus navigation.
+	Gamepad bool
+}
+
+// Consumed returns which user input was consumed by widgets of the default UI
+// during the most recent Update. See UI.Consumed.
+func Consumed() ConsumedInput {
+	return activeUI().Consumed()
+}
+
+// Consumed returns which user input was consumed by widgets during the most
+// recent Update. Input which was not consumed may be handled by the
+// application, such as a click which should pass through to the game world.
+func (u *UI) Consumed() ConsumedInput {
+	return u.consumed
+}
@@ -0,0 +1,97 @@
+package etk_test
+
+import (
+	"testing"
+
+	"codeberg.org/tslocum/etk"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestUIIndependent(t *testing.T) {
+	u1, f1, a1, b1 := setupFakeInput(t, nil)
+	u2, f2, a2, b2 := setupFakeInput(t, nil)
+
+	f1.MoveCursor(50, 50)
+	f1.PressMouse(ebiten.MouseButtonLeft)
+	frame(t, u1, f1)
+	frame(t, u2, f2)
+
+	if a1.clicks != 1 || b1.clicks != 0 || a2.clicks != 0 || b2.clicks != 0 {
+		t.Fatalf("unexpected clicks: expected 1, 0, 0 and 0, got %d, %d, %d and %d", a1.clicks, b1.clicks, a2.clicks, b2.clicks)
+	} else if u1.Focused() != a1 {
+		t.Fatalf("clicked widget was not focused")
+	} else if u2.Focused() == a1 {
+		t.Fatalf("focus leaked between user interfaces")
+	}
+
+	u2.SetFocus(b2)
+	if u1.Focused() != a1 || u2.Focused() != b2 {
+		t.Fatalf("focus leaked between user interfaces")
+	}
+}
+
+func TestUIConsumed(t *testing.T) {
+	u, f, a, _ := setupFakeInput(t, nil)
+
+	u.SetFocus(nil)
+	f.PressKey(ebiten.KeyA)
+	frame(t, u, f)
+	if u.Consumed().Keyboard {
+		t.Fatalf("keyboard input was consumed without a focused widget")
+	}
+	f.ReleaseKey(ebiten.KeyA)
+	frame(t, u, f)
+
+	u.SetFocus(a)
+	f.PressKey(ebiten.KeyA)
+	frame(t, u, f)
+	if !u.Consumed().Keyboard {
+		t.Fatalf("keyboard input handled by focused widget was not consumed")
+	}
+
+	f.MoveCursor(150, 50)
+	frame(t, u, f)
+	consumed := u.Consumed()
+	if !consumed.Mouse || consumed.Touch || consumed.Keyboard {
+		t.Fatalf("unexpected consumed input: %+v", consumed)
+	}
+}
+
+type hoverWidget struct {
+	*testWidget
+	enter, leave int
+}
+
+func (w *hoverWidget) MouseEnter() {
+	w.enter++
+}
+
+func (w *hoverWidget) MouseLeave() {
+	w.leave++
+}
+
+func TestUIHover(t *testing.T) {
+	a, b := &hoverWidget{testWidget: newTestWidget()}, &hoverWidget{testWidget: newTestWidget()}
+	g := etk.NewGrid()
+	g.AddChildAt(a, 0, 0, 1, 1)
+	g.AddChildAt(b, 1, 0, 1, 1)
+	u, f, _, _ := setupFakeInput(t, g)
+
+	f.MoveCursor(50, 50)
+	frame(t, u, f)
+	if u.Hovered() != a || a.enter != 1 || a.leave != 0 {
+		t.Fatalf("widget under cursor was not hovered")
+	}
+
+	f.MoveCursor(60, 50)
+	frame(t, u, f)
+	if a.enter != 1 || a.leave != 0 {
+		t.Fatalf("unexpected hover events: expected 1 and 0, got %d and %d", a.enter, a.leave)
+	}
+
+	f.MoveCursor(150, 50)
+	frame(t, u, f)
+	if u.Hovered() != b || a.leave != 1 || b.enter != 1 {
+		t.Fatalf("hover did not move to widget under cursor")
+	}
+}
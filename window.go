@@ -26,20 +26,24 @@ type Window struct {
 	modified     bool
 	listModified bool
 	firstDraw    bool
+	transition   Transition
+	leaving      *leavingWidget
+	ui           *UI
 }
 
 // NewWindow returns a new Window widget.
 func NewWindow() *Window {
 	w := &Window{
-		Box:       NewBox(),
-		font:      Style.TextFont,
-		fontSize:  Scale(Style.TextSize),
-		frameSize: Scale(4),
-		listSize:  Scale(172),
-		listH:     AlignEnd,
-		listV:     AlignCenter,
-		active:    -1,
-		firstDraw: true,
+		Box:        NewBox(),
+		font:       Style.TextFont,
+		fontSize:   Scale(Style.TextSize),
+		frameSize:  Scale(4),
+		listSize:   Scale(172),
+		listH:      AlignEnd,
+		listV:      AlignCenter,
+		active:     -1,
+		firstDraw:  true,
+		transition: Style.WindowTransition,
 	}
 	w.list = NewList(int(float64(Scale(Style.TextSize))*1.5), w.selectItem, nil)
 	w.listWidget = &WithoutFocus{w.list}
@@ -53,6 +57,18 @@ func (w *Window) SetRect(r image.Rectangle) {
 
 	w.rect = r
 	w.modified = true
+	if currentUI != nil {
+		w.ui = currentUI
+	}
+}
+
+// owner returns the UI which last laid out or drew the window, or the active
+// UI when the window has not been laid out yet.
+func (w *Window) owner() *UI {
+	if w.ui != nil {
+		return w.ui
+	}
+	return activeUI()
 }
 
 // Clip returns whether the widget and its children are restricted to drawing
@@ -113,13 +129,52 @@ func (w *Window) Show(index int) {
 	w.Lock()
 	defer w.Unlock()
 
-	if index >= 0 && index < len(w.children) {
-		w.active = index
-		SetFocus(w.defaultFocus[index])
-	} else {
-		w.active = -1
+	w.show(index)
+}
+
+func (w *Window) show(index int) {
+	if index < 0 || index >= len(w.children) {
+		w.hide()
+		return
+	}
+	u := w.owner()
+	if index != w.active {
+		w.hide()
+		u.transition(w.children[index], w.transition, true)
 	}
+	w.active = index
 	w.modified = true
+	u.SetFocus(w.defaultFocus[index])
+}
+
+// hide hides the active child widget, which continues to be drawn until the
+// window's transition completes.
+func (w *Window) hide() {
+	w.clearLeaving()
+	if w.active >= 0 && w.active < len(w.children) {
+		w.leaving = &leavingWidget{w.children[w.active]}
+		w.owner().transition(w.leaving, w.transition, false)
+	}
+	w.active = -1
+	w.modified = true
+}
+
+// clearLeaving stops drawing the child widget which was hidden.
+func (w *Window) clearLeaving() {
+	if w.leaving == nil {
+		return
+	}
+	w.owner().clearEffect(w.leaving, true)
+	w.leaving = nil
+}
+
+// SetTransition sets the transition shown when a child widget is shown or
+// hidden.
+func (w *Window) SetTransition(t Transition) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.transition = t
 }
 
 // Hide hides the currently visible child widget.
@@ -127,8 +182,7 @@ func (w *Window) Hide() {
 	w.Lock()
 	defer w.Unlock()
 
-	w.active = -1
-	w.modified = true
+	w.hide()
 }
 
 // Children returns the children of the widget.
@@ -136,15 +190,17 @@ func (w *Window) Children() []Widget {
 	w.Lock()
 	defer w.Unlock()
 
+	var children []Widget
+	if w.leaving != nil {
+		children = append(children, w.leaving)
+	}
 	if w.active >= 0 && w.active < len(w.children) {
-		if w.listSize > 0 {
-			return []Widget{w.children[w.active], w.listWidget}
-		}
-		return []Widget{w.children[w.active]}
-	} else if w.listSize > 0 {
-		return []Widget{w.listWidget}
+		children = append(children, w.children[w.active])
 	}
-	return nil
+	if w.listSize > 0 {
+		children = append(children, w.listWidget)
+	}
+	return children
 }
 
 // Clear removes all children from the widget.
@@ -152,6 +208,7 @@ func (w *Window) Clear() {
 	w.Lock()
 	defer w.Unlock()
 
+	w.clearLeaving()
 	w.children = w.children[:0]
 	w.labels = w.labels[:0]
 	w.active = -1
@@ -161,13 +218,26 @@ func (w *Window) Clear() {
 
 func (w *Window) selectItem(index int) (accept bool) {
 	if index >= 0 && index < len(w.children) {
-		w.active = index
-		w.modified = true
-		SetFocus(w.defaultFocus[index])
+		w.show(index)
 	}
 	return true
 }
 
+// Accessibility returns the role, name, value and state of the widget.
+func (w *Window) Accessibility() Accessibility {
+	w.Lock()
+	defer w.Unlock()
+
+	var name string
+	if w.active >= 0 && w.active < len(w.labels) {
+		name = w.labels[w.active]
+	}
+	return Accessibility{
+		Role: RoleWindow,
+		Name: name,
+	}
+}
+
 // HandleKeyboard is called when a keyboard event occurs.
 func (w *Window) HandleKeyboard(ebiten.Key, rune) (handled bool, err error) {
 	return true, nil
@@ -180,6 +250,12 @@ func (w *Window) HandleMouse(cursor image.Point, pressed bool, clicked bool) (ha
 
 // Draw draws the widget on the screen.
 func (w *Window) Draw(screen *ebiten.Image) error {
+	if currentUI != nil {
+		w.ui = currentUI
+	}
+	if w.leaving != nil && !w.owner().drawn(w.leaving) {
+		w.leaving = nil
+	}
 	if w.listModified {
 		w.list.SetItemHeight(int(float64(Scale(w.fontSize)) * 1.5))
 		w.list.Clear()
@@ -269,3 +345,16 @@ func (w *Window) AddChildWithLabel(wgt Widget, defaultFocus Widget, label string
 	w.listModified = true
 	return len(w.children) - 1
 }
+
+// leavingWidget wraps a child widget of a Window which was hidden. The wrapper
+// is not visible, so the child does not receive user input, and it is only
+// drawn until the window's transition completes. The visibility of the child
+// is not modified.
+type leavingWidget struct {
+	Widget
+}
+
+// Visible returns false, as the widget is hidden.
+func (w *leavingWidget) Visible() bool {
+	return false
+}
@@ -0,0 +1,41 @@
+package etk_test
+
+import (
+	"testing"
+
+	"codeberg.org/tslocum/etk"
+)
+
+func TestWindowTransition(t *testing.T) {
+	setupStyle(t)
+
+	a, b := newTestWidget(), newTestWidget()
+	w := etk.NewWindow()
+	w.SetTransition(etk.TransitionFade)
+	w.AddChild(a, b)
+	u, f, _, _ := setupFakeInput(t, w)
+
+	w.Show(0)
+	frame(t, u, f)
+	w.Show(1)
+	frame(t, u, f)
+	if !a.Visible() || !b.Visible() {
+		t.Fatal("window modified the visibility of its children")
+	} else if u.Focused() != b {
+		t.Fatal("shown child was not focused")
+	}
+
+	// The hidden child is drawn during the transition, but it is not focused.
+	u.FocusNext()
+	if u.Focused() != b {
+		t.Fatal("focus moved to hidden child")
+	}
+
+	// Visibility set by the application is kept after the transition.
+	b.SetVisible(false)
+	w.Show(0)
+	frame(t, u, f)
+	if b.Visible() {
+		t.Fatal("window modified the visibility of its children")
+	}
+}